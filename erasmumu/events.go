@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Change notification sent to subscribers (e.g. polytech's offer cache)
+type OfferEvent struct {
+	Type    string `json:"type"` // offer.updated, offer.deleted
+	OfferID string `json:"offerId"`
+	ETag    string `json:"etag,omitempty"`
+}
+
+var webhookClient = &http.Client{Timeout: 5 * time.Second}
+
+// Webhook URLs to notify, comma separated in OFFER_WEBHOOK_URLS
+func webhookURLs() []string {
+	var urls []string
+	for _, u := range strings.Split(os.Getenv("OFFER_WEBHOOK_URLS"), ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// signWebhook authenticates a notification with OFFER_WEBHOOK_SECRET, shared with the subscribers:
+// "sha256=" and the hex HMAC-SHA256 of the body, sent in X-Webhook-Signature
+func signWebhook(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("OFFER_WEBHOOK_SECRET")))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifyOfferChanged posts the event to every webhook in the background.
+// Delivery is best effort: subscribers also revalidate with ETags, so a lost notification only delays them.
+func notifyOfferChanged(eventType, offerID, etag string) {
+	urls := webhookURLs()
+	if len(urls) == 0 {
+		return
+	}
+
+	if os.Getenv("OFFER_WEBHOOK_SECRET") == "" {
+		// Subscribers reject unsigned notifications
+		fmt.Println("Webhooks: OFFER_WEBHOOK_SECRET is not set, notifications aren't sent")
+		return
+	}
+	body, err := json.Marshal(OfferEvent{Type: eventType, OfferID: offerID, ETag: etag})
+	if err != nil {
+		return
+	}
+	signature := signWebhook(body)
+
+	for _, u := range urls {
+		go func(u string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+			if err != nil {
+				fmt.Printf("Webhook %s: %v\n", u, err)
+				return
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Webhook-Signature", signature)
+			resp, err := webhookClient.Do(req)
+			if err != nil {
+				fmt.Printf("Webhook %s: %v\n", u, err)
+				return
+			}
+			resp.Body.Close()
+		}(u)
+	}
+}
+
+// offerETag is a strong validator computed from the offer's JSON representation
+func offerETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches implements the If-None-Match comparison (list of tags or "*")
+func etagMatches(header, etag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -53,6 +53,10 @@ func main() {
 	r.Put("/offer/{id}", updateOffer)
 	r.Delete("/offer/{id}", deleteOffer)
 
+	// Seat reservations (used by polytech when approving registrations)
+	r.Put("/offer/{id}/reservations/{key}", reserveSeat)
+	r.Delete("/offer/{id}/reservations/{key}", releaseSeat)
+
 	fmt.Println("Erasmumu Service starting on :8080")
 	http.ListenAndServe(":8080", r)
 }
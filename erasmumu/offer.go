@@ -21,6 +21,9 @@ type Offer struct {
 	StartDate string             `bson:"startDate" json:"startDate"` // Using string for simplicity as per common lab practices, or time.Time? Let's use string based on prompt "StartDate" usually implies date.
 	EndDate   string             `bson:"endDate" json:"endDate"`
 	Available bool               `bson:"available" json:"available"`
+	Seats     int                `bson:"seats" json:"seats"` // 0 means no seat limit
+	// Reservation keys holding a seat (one per approved registration in polytech)
+	Reservations []string `bson:"reservations,omitempty" json:"-"`
 }
 
 // Helper for JSON responses
@@ -74,7 +77,22 @@ func getOffer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jsonResponse(w, http.StatusOK, o)
+	// Conditional GET: clients caching offers revalidate with If-None-Match
+	body, err := json.Marshal(o)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	etag := offerETag(body)
+	w.Header().Set("ETag", etag)
+	if match := r.Header.Get("If-None-Match"); match != "" && etagMatches(match, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(body, '\n'))
 }
 
 // GET /offer?domain=<domain>&city=<city>
@@ -153,6 +171,7 @@ func updateOffer(w http.ResponseWriter, r *http.Request) {
         "startDate": o.StartDate,
         "endDate": o.EndDate,
         "available": o.Available,
+        "seats": o.Seats,
     }
 
 	result, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": updateData})
@@ -167,6 +186,12 @@ func updateOffer(w http.ResponseWriter, r *http.Request) {
     }
 
     o.ID = id
+    etag := ""
+    if body, err := json.Marshal(o); err == nil {
+        etag = offerETag(body)
+    }
+    notifyOfferChanged("offer.updated", idStr, etag)
+
 	jsonResponse(w, http.StatusOK, o)
 }
 
@@ -192,6 +217,7 @@ func deleteOffer(w http.ResponseWriter, r *http.Request) {
         http.Error(w, "Offer not found", http.StatusNotFound)
         return
     }
+    notifyOfferChanged("offer.deleted", idStr, "")
 
 	w.WriteHeader(http.StatusNoContent)
 }
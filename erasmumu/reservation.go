@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PUT /offer/{id}/reservations/{key}
+// Reserves a seat for the caller-chosen key. Idempotent: reserving twice with the same key holds a single seat.
+func reserveSeat(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	key := chi.URLParam(r, "key")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Single conditional update so concurrent reservations can't overbook the offer
+	filter := bson.M{
+		"_id":          id,
+		"available":    true,
+		"reservations": bson.M{"$ne": key},
+		"$or": bson.A{
+			bson.M{"seats": bson.M{"$not": bson.M{"$gt": 0}}},
+			bson.M{"$expr": bson.M{"$lt": bson.A{
+				bson.M{"$size": bson.M{"$ifNull": bson.A{"$reservations", bson.A{}}}},
+				"$seats",
+			}}},
+		},
+	}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$push": bson.M{"reservations": key}})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if result.ModifiedCount == 1 {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	// Nothing modified: find out why
+	var o Offer
+	err = collection.FindOne(ctx, bson.M{"_id": id, "available": true}).Decode(&o)
+	if err == mongo.ErrNoDocuments {
+		http.Error(w, "Offer not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, k := range o.Reservations {
+		if k == key {
+			// Already holding a seat
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+	http.Error(w, "No seats left", http.StatusConflict)
+}
+
+// DELETE /offer/{id}/reservations/{key}
+// Releases the seat held by key. Idempotent: releasing an unknown key (or a deleted offer) succeeds.
+func releaseSeat(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	key := chi.URLParam(r, "key")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$pull": bson.M{"reservations": key}})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
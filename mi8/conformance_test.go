@@ -0,0 +1,249 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	pb "mi8/proto"
+
+	"github.com/alicebob/miniredis/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// The conformance suite checks that every NewsRepository scores cities the same way: tag impacts,
+// clamping to 0, country of the first news, rankings, country aggregates, reversal of deleted or
+// updated news, and decay. The Redis repository runs on miniredis.
+// The memory repository starts with sample news, the cities and countries of the suite are prefixed
+// to tell them apart.
+func TestRepositoryConformance(t *testing.T) {
+	repos := map[string]func(t *testing.T) NewsRepository{
+		"memory": func(t *testing.T) NewsRepository { return NewArrayNewsRepository() },
+		"redis": func(t *testing.T) NewsRepository {
+			t.Setenv("REDIS_ADDR", miniredis.RunT(t).Addr())
+			r, err := NewRedisNewsRepository()
+			if err != nil {
+				t.Fatalf("redis: %v", err)
+			}
+			return r
+		},
+	}
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			c := &conformanceRun{t: t, repo: repo(t)}
+			c.run()
+		})
+	}
+}
+
+const conformancePrefix = "Conformance-"
+
+type conformanceRun struct {
+	t     *testing.T
+	repo  NewsRepository
+	count int
+}
+
+// city prefixes the names of cities and countries of the suite
+func (c *conformanceRun) city(name string) string {
+	return conformancePrefix + name
+}
+
+func (c *conformanceRun) create(city, country, date string, tags ...string) *pb.News {
+	c.t.Helper()
+	c.count++
+	news, err := c.repo.CreateNews(&pb.News{
+		Name:    "Conformance news " + strconv.Itoa(c.count),
+		Source:  "conformance",
+		Date:    date,
+		Tags:    tags,
+		City:    c.city(city),
+		Country: c.city(country),
+	})
+	if err != nil {
+		c.t.Errorf("create news in %s: %v", city, err)
+		return &pb.News{}
+	}
+	return news
+}
+
+func (c *conformanceRun) expectScore(step, city, country, date string, safety, economy, qol, culture int32) {
+	c.t.Helper()
+	want := &pb.CityScore{
+		City:          c.city(city),
+		Country:       c.city(country),
+		Safety:        safety,
+		Economy:       economy,
+		QualityOfLife: qol,
+		Culture:       culture,
+		LastUpdated:   date,
+	}
+	got, err := c.repo.GetCityScore(c.city(city))
+	if err != nil {
+		c.t.Errorf("%s: score of %s: %v", step, city, err)
+		return
+	}
+	if !proto.Equal(got, want) {
+		c.t.Errorf("%s: score of %s is {%v}, want {%v}", step, city, got, want)
+	}
+}
+
+// expectRanking checks the order of the cities of the suite in a ranking
+func (c *conformanceRun) expectRanking(step string, ranking cityRanking, cities ...string) {
+	c.t.Helper()
+	top, err := c.repo.GetTopCities(ranking, 0)
+	if err != nil {
+		c.t.Errorf("%s: top cities: %v", step, err)
+		return
+	}
+	var got []string
+	for _, score := range top {
+		if strings.HasPrefix(score.City, conformancePrefix) {
+			got = append(got, strings.TrimPrefix(score.City, conformancePrefix))
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(cities) {
+		c.t.Errorf("%s: ranking is %v, want %v", step, got, cities)
+	}
+}
+
+func (c *conformanceRun) expectCountry(step, country string, cities int, mean, median *pb.DimensionScores, min, max string) {
+	c.t.Helper()
+	got, err := c.repo.GetCountryScore(c.city(country))
+	if err != nil {
+		c.t.Errorf("%s: score of %s: %v", step, country, err)
+		return
+	}
+	if got.CityCount != int32(cities) || !proto.Equal(got.Mean, mean) || !proto.Equal(got.Median, median) {
+		c.t.Errorf("%s: score of %s has %d cities, mean {%v}, median {%v}, want %d, {%v}, {%v}", step, country, got.CityCount, got.Mean, got.Median, cities, mean, median)
+	}
+	if got.MinCity.GetCity() != c.city(min) || got.MaxCity.GetCity() != c.city(max) {
+		c.t.Errorf("%s: cities of %s go from %s to %s, want %s to %s", step, country, got.MinCity.GetCity(), got.MaxCity.GetCity(), c.city(min), c.city(max))
+	}
+}
+
+// expectCountryRanking checks the order of the countries of the suite in a ranking
+func (c *conformanceRun) expectCountryRanking(step string, dimension pb.ScoreDimension, countries ...string) {
+	c.t.Helper()
+	by, _ := rankedFieldOf(dimension)
+	top, err := c.repo.GetTopCountries(by, 0)
+	if err != nil {
+		c.t.Errorf("%s: top countries: %v", step, err)
+		return
+	}
+	var got []string
+	for _, score := range top {
+		if strings.HasPrefix(score.Country, conformancePrefix) {
+			got = append(got, strings.TrimPrefix(score.Country, conformancePrefix))
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(countries) {
+		c.t.Errorf("%s: ranking is %v, want %v", step, got, countries)
+	}
+}
+
+func (c *conformanceRun) expectHistory(step, city string, records int, last scorePoint) {
+	c.t.Helper()
+	points, err := c.repo.GetCityScoreHistory(c.city(city), time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		c.t.Errorf("%s: history of %s: %v", step, city, err)
+		return
+	}
+	if len(points) != records {
+		c.t.Errorf("%s: history of %s has %d records, want %d", step, city, len(points), records)
+		return
+	}
+	got := points[len(points)-1]
+	got.at = time.Time{}
+	if got != last {
+		c.t.Errorf("%s: last record of %s is %+v, want %+v", step, city, got, last)
+	}
+}
+
+func (c *conformanceRun) run() {
+	// Without decay first, the news being dated in the past
+	c.t.Cleanup(func(h halfLives) func() { return func() { scoreHalfLives = h } }(scoreHalfLives))
+	scoreHalfLives = halfLives{}
+
+	d1, d2, d3 := "2026-01-01T10:00:00Z", "2026-01-02T10:00:00Z", "2026-01-03T10:00:00Z"
+
+	culture := c.create("A", "France", d1, "Culture")
+	c.expectScore("impact from the base score", "A", "France", d1, 1000, 1015, 1040, 1075)
+
+	crime := c.create("A", "Italy", d2, "crime", "CRIME")
+	c.expectScore("tags are case insensitive, country is kept", "A", "France", d2, 760, 915, 880, 995)
+
+	disasters := make([]string, 11)
+	for i := range disasters {
+		disasters[i] = "Disaster"
+	}
+	disaster := c.create("B", "Spain", d1, disasters...)
+	c.expectScore("scores are clamped to 0", "B", "Spain", d1, 0, 230, 10, 670)
+
+	c.create("C", "Germany", d1, "Innovation")
+	c.create("D", "Germany", d1)
+	c.create("E", "Germany", d1, "Unknown")
+	c.expectScore("unknown tags have no impact", "E", "Germany", d1, 1000, 1000, 1000, 1000)
+	c.expectRanking("ranking by clamped total, ties by name", totalRanking, "C", "E", "D", "A", "B")
+
+	if err := c.repo.DeleteNews(crime.Id); err != nil {
+		c.t.Errorf("delete news: %v", err)
+	}
+	c.expectScore("deletion reverses the impact", "A", "France", d2, 1000, 1015, 1040, 1075)
+	if _, err := c.repo.GetNews(crime.Id); !errors.Is(err, errNewsNotFound) {
+		c.t.Errorf("deleted news: got %v, want %v", err, errNewsNotFound)
+	}
+
+	updated := proto.Clone(culture).(*pb.News)
+	updated.Tags = []string{"Healthcare"}
+	updated.Date = d3
+	if _, err := c.repo.UpdateNews(updated); err != nil {
+		c.t.Errorf("update news: %v", err)
+	}
+	c.expectScore("update moves the impact", "A", "France", d3, 1030, 1020, 1030, 1000)
+	c.expectHistory("every change is recorded", "A", 5, scorePoint{safety: 1030, economy: 1020, qol: 1030, culture: 1000})
+
+	if err := c.repo.DeleteNews(disaster.Id); err != nil {
+		c.t.Errorf("delete news: %v", err)
+	}
+	c.expectScore("reversal is exact below 0", "B", "Spain", d1, 1000, 1000, 1000, 1000)
+	c.expectRanking("ranking follows reversals", totalRanking, "C", "A", "E", "D", "B")
+	c.expectRanking("ranking by dimension", cityRanking{weights: scoreVector{safety: 1}}, "A", "C", "E", "D", "B")
+	c.expectRanking("ranking by weights", cityRanking{weights: scoreVector{economy: 2, qol: 1}}, "C", "A", "E", "D", "B")
+	c.expectRanking("ranking in a country", cityRanking{weights: scoreVector{culture: 1}, country: c.city("Germany")}, "C", "E", "D")
+	c.expectRanking("weighted ranking in a country", cityRanking{weights: scoreVector{safety: 0.5, culture: 1.5}, country: c.city("Germany")}, "C", "E", "D")
+
+	scores := func(safety, economy, qol, culture, total float64) *pb.DimensionScores {
+		return &pb.DimensionScores{Safety: safety, Economy: economy, QualityOfLife: qol, Culture: culture, Total: total}
+	}
+	c.expectCountry("country aggregates", "Germany", 3, scores(3020.0/3, 1020, 3030.0/3, 3005.0/3, 12115.0/3), scores(1000, 1000, 1000, 1000, 4000), "D", "C")
+	c.expectCountry("country of one city", "France", 1, scores(1030, 1020, 1030, 1000, 4080), scores(1030, 1020, 1030, 1000, 4080), "A", "A")
+	c.expectCountryRanking("countries by mean total", pb.ScoreDimension_TOTAL, "France", "Germany", "Spain")
+	c.expectCountryRanking("countries by mean dimension, ties by name", pb.ScoreDimension_ECONOMY, "Germany", "France", "Spain")
+
+	// A news dated one half-life ago weighs half its impact. News without a date don't decay yet.
+	scoreHalfLives = halfLives{time.Hour, time.Hour, 2 * time.Hour, 0}
+	hourAgo := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	halved := c.create("F", "Spain", hourAgo, "Crime")
+	c.expectScore("impact decays from the news date", "F", "Spain", hourAgo, 940, 975, 943, 960)
+	c.create("F", "Spain", "", "Crime")
+	c.expectScore("news without a date start decaying now", "F", "Spain", hourAgo, 820, 925, 863, 920)
+	if err := c.repo.DeleteNews(halved.Id); err != nil {
+		c.t.Errorf("delete news: %v", err)
+	}
+	c.expectScore("reversal removes what remains of the impact", "F", "Spain", hourAgo, 880, 950, 920, 960)
+	c.expectCountry("country aggregates with decay", "Spain", 2, scores(940, 975, 960, 980, 3855), scores(940, 975, 960, 980, 3855), "F", "B")
+	c.expectCountry("country without decayed news", "France", 1, scores(1030, 1020, 1030, 1000, 4080), scores(1030, 1020, 1030, 1000, 4080), "A", "A")
+	c.expectCountryRanking("countries by mean total with decay", pb.ScoreDimension_TOTAL, "France", "Germany", "Spain")
+	c.expectCountryRanking("countries by mean dimension with decay", pb.ScoreDimension_CULTURE, "Germany", "France", "Spain")
+
+	if _, err := c.repo.GetCityScore(c.city("Missing")); !errors.Is(err, errCityNotFound) {
+		c.t.Errorf("unknown city: got %v, want %v", err, errCityNotFound)
+	}
+	if _, err := c.repo.GetCountryScore(c.city("Missing")); !errors.Is(err, errCountryNotFound) {
+		c.t.Errorf("unknown country: got %v, want %v", err, errCountryNotFound)
+	}
+}
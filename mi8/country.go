@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	pb "mi8/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Country scores.
+//
+// The score of a country aggregates the scores as read (rounded and clamped to 0) of its cities,
+// the cities whose first news was in the country: mean and median per dimension and of the
+// total, and the cities with the lowest and highest total, ties going to the first and last names
+// like ZRANGE and ZREVRANGE. Countries are ranked by the mean of a dimension, ties by name,
+// descending.
+
+var errCountryNotFound = errors.New("no scored city in the country")
+
+// dimensionScores builds DimensionScores from values by field of rankedFields
+func dimensionScores(values map[string]float64) *pb.DimensionScores {
+	return &pb.DimensionScores{
+		Safety:        values["safety"],
+		Economy:       values["economy"],
+		QualityOfLife: values["qol"],
+		Culture:       values["culture"],
+		Total:         values["total"],
+	}
+}
+
+// of reads the field from aggregated scores
+func (f rankedField) of(d *pb.DimensionScores) float64 {
+	switch f.field {
+	case "safety":
+		return d.Safety
+	case "economy":
+		return d.Economy
+	case "qol":
+		return d.QualityOfLife
+	case "culture":
+		return d.Culture
+	}
+	return d.Total
+}
+
+// aggregateCountry computes the score of a country from the scores of its cities
+func aggregateCountry(country string, cities []*pb.CityScore) *pb.CountryScore {
+	n := len(cities)
+	byTotal := make([]*pb.CityScore, n)
+	copy(byTotal, cities)
+	total := func(c *pb.CityScore) float64 { return totalRanking.value(cityScoreVector(c)) }
+	sort.Slice(byTotal, func(i, j int) bool {
+		ti, tj := total(byTotal[i]), total(byTotal[j])
+		if ti != tj {
+			return ti < tj
+		}
+		return byTotal[i].City < byTotal[j].City
+	})
+
+	mean, median := map[string]float64{}, map[string]float64{}
+	for _, f := range rankedFields {
+		ranking := cityRanking{weights: f.weights}
+		values := make([]float64, n)
+		var sum float64
+		for i, c := range cities {
+			values[i] = ranking.value(cityScoreVector(c))
+			sum += values[i]
+		}
+		sort.Float64s(values)
+		mean[f.field] = sum / float64(n)
+		median[f.field] = (values[(n-1)/2] + values[n/2]) / 2
+	}
+	return &pb.CountryScore{
+		Country:   country,
+		CityCount: int32(n),
+		Mean:      dimensionScores(mean),
+		Median:    dimensionScores(median),
+		MinCity:   byTotal[0],
+		MaxCity:   byTotal[n-1],
+	}
+}
+
+// cityScoreVector gives back the scores of a CityScore
+func cityScoreVector(c *pb.CityScore) scoreVector {
+	return scoreVector{float64(c.Safety), float64(c.Economy), float64(c.QualityOfLife), float64(c.Culture)}
+}
+
+func (s *server) GetCountryScore(ctx context.Context, in *pb.GetCountryScoreRequest) (*pb.CountryScore, error) {
+	score, err := s.repo.GetCountryScore(in.Country)
+	if errors.Is(err, errCountryNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return score, err
+}
+
+func (s *server) GetTopCountries(ctx context.Context, in *pb.GetTopCountriesRequest) (*pb.CountryScoreList, error) {
+	by, err := rankedFieldOf(in.Dimension)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	scores, err := s.repo.GetTopCountries(by, int(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CountryScoreList{Scores: scores}, nil
+}
+
+func (s *server) ListCitiesInCountry(ctx context.Context, in *pb.ListCitiesInCountryRequest) (*pb.CityScoreList, error) {
+	if in.Country == "" {
+		return nil, status.Error(codes.InvalidArgument, "country is required")
+	}
+	scores, err := s.repo.GetTopCities(cityRanking{weights: totalRanking.weights, country: in.Country}, int(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CityScoreList{Scores: scores}, nil
+}
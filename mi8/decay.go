@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	pb "mi8/proto"
+)
+
+// Score decay.
+//
+// The impact of a news on its city fades with exponential decay from the news date, with a
+// half-life per dimension. Scores are stored decayed to the time of their last update: reading
+// them decays them further to now, updating them decays them to now before adding the impact.
+// Both only depend on the distance to the base score, which is what decays.
+
+const baseScore = 1000
+
+// Half-lives of the impacts, 0 for impacts that never fade
+type halfLives struct {
+	safety, economy, qol, culture time.Duration
+}
+
+var scoreHalfLives = halfLives{
+	safety:  90 * 24 * time.Hour,
+	economy: 180 * 24 * time.Hour,
+	qol:     180 * 24 * time.Hour,
+	culture: 365 * 24 * time.Hour,
+}
+
+// loadHalfLives reads SCORE_HALF_LIFE_SAFETY, _ECONOMY, _QUALITY_OF_LIFE and _CULTURE, Go
+// durations such as "2160h", 0 to disable decay
+func loadHalfLives() error {
+	for name, halfLife := range map[string]*time.Duration{
+		"SCORE_HALF_LIFE_SAFETY":          &scoreHalfLives.safety,
+		"SCORE_HALF_LIFE_ECONOMY":         &scoreHalfLives.economy,
+		"SCORE_HALF_LIFE_QUALITY_OF_LIFE": &scoreHalfLives.qol,
+		"SCORE_HALF_LIFE_CULTURE":         &scoreHalfLives.culture,
+	} {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return fmt.Errorf("%s: invalid duration %q", name, v)
+		}
+		*halfLife = d
+	}
+	return nil
+}
+
+func decayFactor(age, halfLife time.Duration) float64 {
+	if halfLife <= 0 || age <= 0 {
+		return 1
+	}
+	return math.Exp2(-age.Seconds() / halfLife.Seconds())
+}
+
+// scoreVector holds the four dimensions of a city, unclamped
+type scoreVector struct {
+	safety, economy, qol, culture float64
+}
+
+var baseScores = scoreVector{baseScore, baseScore, baseScore, baseScore}
+
+func (v scoreVector) add(o scoreVector) scoreVector {
+	return scoreVector{v.safety + o.safety, v.economy + o.economy, v.qol + o.qol, v.culture + o.culture}
+}
+
+// contribution is what an impact applied at `at` still weighs at `now`
+func (h halfLives) contribution(delta scoreDelta, at, now time.Time) scoreVector {
+	age := now.Sub(at)
+	return scoreVector{
+		float64(delta.safety) * decayFactor(age, h.safety),
+		float64(delta.economy) * decayFactor(age, h.economy),
+		float64(delta.qol) * decayFactor(age, h.qol),
+		float64(delta.culture) * decayFactor(age, h.culture),
+	}
+}
+
+// decay brings scores decayed to `from` to `now`
+func (h halfLives) decay(v scoreVector, from, now time.Time) scoreVector {
+	age := now.Sub(from)
+	fade := func(score float64, halfLife time.Duration) float64 {
+		return baseScore + (score-baseScore)*decayFactor(age, halfLife)
+	}
+	return scoreVector{fade(v.safety, h.safety), fade(v.economy, h.economy), fade(v.qol, h.qol), fade(v.culture, h.culture)}
+}
+
+func clampScore(v float64) int32 {
+	if v < 0 {
+		return 0
+	}
+	return int32(math.Round(v))
+}
+
+// total ranks the cities
+func (v scoreVector) total() float64 {
+	return float64(clampScore(v.safety)) + float64(clampScore(v.economy)) + float64(clampScore(v.qol)) + float64(clampScore(v.culture))
+}
+
+func (v scoreVector) toProto(city, country, lastUpdated string) *pb.CityScore {
+	return &pb.CityScore{
+		City:          city,
+		Country:       country,
+		Safety:        clampScore(v.safety),
+		Economy:       clampScore(v.economy),
+		QualityOfLife: clampScore(v.qol),
+		Culture:       clampScore(v.culture),
+		LastUpdated:   lastUpdated,
+	}
+}
+
+// appliedImpact is the impact a news had on its city's scores, from the time it started fading.
+// It is kept to reverse the impact with the rules it was applied with.
+type appliedImpact struct {
+	delta scoreDelta
+	at    time.Time
+}
+
+// newsTime is when the impact of a news starts fading: its date, or now without a valid date
+func newsTime(news *pb.News, now time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339, news.Date); err == nil && t.Before(now) {
+		return t
+	}
+	return now
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	pb "mi8/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Score history.
+//
+// The scores of a city, as read (rounded and clamped to 0), are recorded every time news change
+// them. Between two records a city's scores only decay towards the base score. Records older than
+// the retention are dropped as new ones come.
+
+var scoreHistoryRetention = 2 * 365 * 24 * time.Hour
+
+// Under this relative change of the total over the history, a city is stable
+const trendThreshold = 0.01
+
+// scorePoint is a record of a city's scores
+type scorePoint struct {
+	at                            time.Time
+	safety, economy, qol, culture int32
+}
+
+func pointOf(v scoreVector, at time.Time) scorePoint {
+	return scorePoint{at, clampScore(v.safety), clampScore(v.economy), clampScore(v.qol), clampScore(v.culture)}
+}
+
+func (p scorePoint) total() int32 {
+	return p.safety + p.economy + p.qol + p.culture
+}
+
+// bucketStart truncates t to its day, week (from Monday) or month, in UTC
+func bucketStart(t time.Time, bucket pb.HistoryBucket) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch bucket {
+	case pb.HistoryBucket_WEEK:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case pb.HistoryBucket_MONTH:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// bucketsBefore goes back n buckets from t
+func bucketsBefore(t time.Time, bucket pb.HistoryBucket, n int) time.Time {
+	switch bucket {
+	case pb.HistoryBucket_WEEK:
+		return t.AddDate(0, 0, -7*n)
+	case pb.HistoryBucket_MONTH:
+		return t.AddDate(0, -n, 0)
+	}
+	return t.AddDate(0, 0, -n)
+}
+
+// aggregateHistory averages the points, ordered by time, per bucket
+func aggregateHistory(points []scorePoint, bucket pb.HistoryBucket) []*pb.CityScorePoint {
+	type sums struct {
+		start                                time.Time
+		safety, economy, qol, culture, count int64
+	}
+	var buckets []*sums
+	for _, p := range points {
+		start := bucketStart(p.at, bucket)
+		if len(buckets) == 0 || !buckets[len(buckets)-1].start.Equal(start) {
+			buckets = append(buckets, &sums{start: start})
+		}
+		b := buckets[len(buckets)-1]
+		b.safety += int64(p.safety)
+		b.economy += int64(p.economy)
+		b.qol += int64(p.qol)
+		b.culture += int64(p.culture)
+		b.count++
+	}
+
+	result := make([]*pb.CityScorePoint, 0, len(buckets))
+	for _, b := range buckets {
+		mean := func(sum int64) int32 {
+			return int32(math.Round(float64(sum) / float64(b.count)))
+		}
+		point := &pb.CityScorePoint{
+			Start:         b.start.Format(time.RFC3339),
+			Safety:        mean(b.safety),
+			Economy:       mean(b.economy),
+			QualityOfLife: mean(b.qol),
+			Culture:       mean(b.culture),
+			Samples:       int32(b.count),
+		}
+		point.Total = point.Safety + point.Economy + point.QualityOfLife + point.Culture
+		result = append(result, point)
+	}
+	return result
+}
+
+// historyTrend fits a line through the totals of the points: the change it gives over the history,
+// relative to the mean total, tells whether the city improves or declines
+func historyTrend(points []*pb.CityScorePoint) pb.Trend {
+	n := float64(len(points))
+	if n < 2 {
+		return pb.Trend_TREND_UNKNOWN
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, p := range points {
+		x, y := float64(i), float64(p.Total)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	meanY := sumY / n
+	if meanY == 0 {
+		return pb.Trend_TREND_STABLE
+	}
+	change := slope * (n - 1) / meanY
+	switch {
+	case change > trendThreshold:
+		return pb.Trend_TREND_IMPROVING
+	case change < -trendThreshold:
+		return pb.Trend_TREND_DECLINING
+	}
+	return pb.Trend_TREND_STABLE
+}
+
+func (s *server) GetCityScoreHistory(ctx context.Context, in *pb.GetCityScoreHistoryRequest) (*pb.CityScoreHistory, error) {
+	to := time.Now()
+	if in.To != "" {
+		t, err := time.Parse(time.RFC3339, in.To)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "to is not RFC 3339")
+		}
+		to = t
+	}
+	from := bucketsBefore(bucketStart(to, in.Bucket), in.Bucket, 29)
+	if in.From != "" {
+		t, err := time.Parse(time.RFC3339, in.From)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "from is not RFC 3339")
+		}
+		from = t
+	}
+	if from.After(to) {
+		return nil, status.Error(codes.InvalidArgument, "from is after to")
+	}
+
+	points, err := s.repo.GetCityScoreHistory(in.City, from, to)
+	if errors.Is(err, errCityNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	aggregated := aggregateHistory(points, in.Bucket)
+	return &pb.CityScoreHistory{
+		City:   in.City,
+		Bucket: in.Bucket,
+		Points: aggregated,
+		Trend:  historyTrend(aggregated),
+	}, nil
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	pb "mi8/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Impact rules.
+//
+// The impact of a news on the scores of its city is the sum of the impacts of its tags, given by
+// rules matching a tag or one of its synonyms, case insensitively. Rules are read from a JSON file
+// (IMPACT_RULES_FILE, an ImpactRuleSet in protobuf JSON) reloaded when it changes, and can be
+// changed with the admin RPCs, which write the file back. Every change increments the version.
+// Without a file the default rules are used and changes are kept in memory.
+
+var (
+	errRuleNotFound        = errors.New("impact rule not found")
+	errRuleVersionConflict = errors.New("impact rules were changed since the expected version")
+)
+
+// scoreDelta is the impact of a news on the scores of its city
+type scoreDelta struct {
+	safety, economy, qol, culture int64
+}
+
+func (d scoreDelta) scaled(sign int64) scoreDelta {
+	return scoreDelta{sign * d.safety, sign * d.economy, sign * d.qol, sign * d.culture}
+}
+
+func (d scoreDelta) add(o scoreDelta) scoreDelta {
+	return scoreDelta{d.safety + o.safety, d.economy + o.economy, d.qol + o.qol, d.culture + o.culture}
+}
+
+func (d scoreDelta) toProto() *pb.ScoreDelta {
+	return &pb.ScoreDelta{Safety: int32(d.safety), Economy: int32(d.economy), QualityOfLife: int32(d.qol), Culture: int32(d.culture)}
+}
+
+func deltaFromProto(d *pb.ScoreDelta) scoreDelta {
+	return scoreDelta{int64(d.GetSafety()), int64(d.GetEconomy()), int64(d.GetQualityOfLife()), int64(d.GetCulture())}
+}
+
+func defaultImpactRules() []*pb.ImpactRule {
+	rule := func(tag string, safety, economy, qol, culture int32) *pb.ImpactRule {
+		return &pb.ImpactRule{Tag: tag, Impact: &pb.ScoreDelta{Safety: safety, Economy: economy, QualityOfLife: qol, Culture: culture}}
+	}
+	return []*pb.ImpactRule{
+		rule("innovation", 20, 60, 30, 5),
+		rule("culture", 0, 15, 40, 75),
+		rule("healthcare", 30, 20, 30, 0),
+		rule("entertainment", 0, 20, 25, 35),
+		rule("crisis", -80, -100, -60, -30),
+		rule("crime", -120, -50, -80, -40),
+		rule("disaster", -100, -70, -90, -30),
+	}
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// ruleStore holds the current rules, replaced as a whole on every change
+type ruleStore struct {
+	mu    sync.RWMutex
+	set   *pb.ImpactRuleSet
+	index map[string]*pb.ImpactRule // by normalized tag and synonym
+	// File the rules are kept in, and its modification time when last read or written
+	file    string
+	modTime time.Time
+}
+
+// Rules used by the repositories, from IMPACT_RULES_FILE when set in main
+var impactRules = newRuleStore()
+
+func newRuleStore() *ruleStore {
+	s := &ruleStore{}
+	s.replace(&pb.ImpactRuleSet{Version: 1, UpdatedAt: time.Now().Format(time.RFC3339), Rules: defaultImpactRules()})
+	return s
+}
+
+func indexRules(rules []*pb.ImpactRule) (map[string]*pb.ImpactRule, error) {
+	index := map[string]*pb.ImpactRule{}
+	for _, rule := range rules {
+		if normalizeTag(rule.Tag) == "" {
+			return nil, errors.New("impact rule without a tag")
+		}
+		for _, tag := range append([]string{rule.Tag}, rule.Synonyms...) {
+			key := normalizeTag(tag)
+			if other, ok := index[key]; ok {
+				return nil, fmt.Errorf("tag %q is in the rules of %q and %q", tag, other.Tag, rule.Tag)
+			}
+			index[key] = rule
+		}
+	}
+	return index, nil
+}
+
+// replace switches to a validated rule set, s.mu must be held unless s isn't shared yet
+func (s *ruleStore) replace(set *pb.ImpactRuleSet) error {
+	index, err := indexRules(set.Rules)
+	if err != nil {
+		return err
+	}
+	s.set, s.index = set, index
+	return nil
+}
+
+// impact sums the impacts of the tags, also telling which tags matched a rule
+func (s *ruleStore) impact(tags []string) (delta scoreDelta, matched, unmatched []string, version int64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, tag := range tags {
+		rule, ok := s.index[normalizeTag(tag)]
+		if !ok {
+			unmatched = append(unmatched, tag)
+			continue
+		}
+		matched = append(matched, tag)
+		delta = delta.add(deltaFromProto(rule.Impact))
+	}
+	return delta, matched, unmatched, s.set.Version
+}
+
+// calculateImpact gives the impact of a news with these tags on its city's scores
+func calculateImpact(tags []string) scoreDelta {
+	delta, _, _, _ := impactRules.impact(tags)
+	return delta
+}
+
+func (s *ruleStore) list() *pb.ImpactRuleSet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return proto.Clone(s.set).(*pb.ImpactRuleSet)
+}
+
+// update applies a change to a copy of the rules, then saves them as the next version
+func (s *ruleStore) update(expectedVersion int64, change func(rules []*pb.ImpactRule) ([]*pb.ImpactRule, error)) (*pb.ImpactRuleSet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if expectedVersion != 0 && expectedVersion != s.set.Version {
+		return nil, errRuleVersionConflict
+	}
+
+	next := proto.Clone(s.set).(*pb.ImpactRuleSet)
+	rules, err := change(next.Rules)
+	if err != nil {
+		return nil, err
+	}
+	next.Rules = rules
+	next.Version++
+	next.UpdatedAt = time.Now().Format(time.RFC3339)
+	if _, err := indexRules(next.Rules); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.save(next); err != nil {
+		return nil, err
+	}
+	s.replace(next)
+	return proto.Clone(next).(*pb.ImpactRuleSet), nil
+}
+
+// save writes the rules to the file, if any, through a temporary file renamed at the end
+func (s *ruleStore) save(set *pb.ImpactRuleSet) error {
+	if s.file == "" {
+		return nil
+	}
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(set)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.file), ".rules-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.file); err != nil {
+		return err
+	}
+	if info, err := os.Stat(s.file); err == nil {
+		s.modTime = info.ModTime()
+	}
+	return nil
+}
+
+// load reads the rules from the file when it changed since last read. A new file gets the current
+// rules. The version goes on from the current one if the file's is behind.
+func (s *ruleStore) load(file string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.file = file
+
+	info, err := os.Stat(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return s.save(s.set)
+	}
+	if err != nil {
+		return err
+	}
+	if info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	set := &pb.ImpactRuleSet{}
+	if err := protojson.Unmarshal(data, set); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	if set.Version <= s.set.Version {
+		set.Version = s.set.Version + 1
+	}
+	if set.UpdatedAt == "" {
+		set.UpdatedAt = info.ModTime().Format(time.RFC3339)
+	}
+	if err := s.replace(set); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	s.modTime = info.ModTime()
+	fmt.Printf("Impact rules loaded from %s, version %d\n", file, set.Version)
+	return nil
+}
+
+// watch reloads the file periodically, keeping the current rules when it is invalid
+func (s *ruleStore) watch(file string, interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := s.load(file); err != nil {
+			fmt.Printf("Error reloading impact rules: %v\n", err)
+		}
+	}
+}
+
+func ruleError(err error) error {
+	switch {
+	case errors.Is(err, errRuleVersionConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, errRuleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+func (s *server) ListImpactRules(ctx context.Context, in *pb.ListImpactRulesRequest) (*pb.ImpactRuleSet, error) {
+	return impactRules.list(), nil
+}
+
+func (s *server) PutImpactRule(ctx context.Context, in *pb.PutImpactRuleRequest) (*pb.ImpactRuleSet, error) {
+	rule := in.GetRule()
+	if normalizeTag(rule.GetTag()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "tag is required")
+	}
+	if rule.Impact == nil {
+		rule.Impact = &pb.ScoreDelta{}
+	}
+	set, err := impactRules.update(in.ExpectedVersion, func(rules []*pb.ImpactRule) ([]*pb.ImpactRule, error) {
+		for i, r := range rules {
+			if normalizeTag(r.Tag) == normalizeTag(rule.Tag) {
+				rules[i] = rule
+				return rules, nil
+			}
+		}
+		return append(rules, rule), nil
+	})
+	return set, ruleError(err)
+}
+
+func (s *server) DeleteImpactRule(ctx context.Context, in *pb.DeleteImpactRuleRequest) (*pb.ImpactRuleSet, error) {
+	set, err := impactRules.update(in.ExpectedVersion, func(rules []*pb.ImpactRule) ([]*pb.ImpactRule, error) {
+		for i, r := range rules {
+			if normalizeTag(r.Tag) == normalizeTag(in.Tag) {
+				return append(rules[:i], rules[i+1:]...), nil
+			}
+		}
+		return nil, errRuleNotFound
+	})
+	return set, ruleError(err)
+}
+
+// PreviewNewsImpact applies what remains of the impact, given the news date, to the current scores
+// of the city as read: scores below 0 are read as 0, so the projection can be above what creating
+// the news would give.
+func (s *server) PreviewNewsImpact(ctx context.Context, in *pb.News) (*pb.ImpactPreview, error) {
+	delta, matched, unmatched, version := impactRules.impact(in.Tags)
+	preview := &pb.ImpactPreview{
+		RulesVersion:  version,
+		MatchedTags:   matched,
+		UnmatchedTags: unmatched,
+		Impact:        delta.toProto(),
+	}
+	if in.City == "" {
+		return preview, nil
+	}
+
+	current, err := s.repo.GetCityScore(in.City)
+	if errors.Is(err, errCityNotFound) {
+		current = &pb.CityScore{City: in.City, Country: in.Country, Safety: 1000, Economy: 1000, QualityOfLife: 1000, Culture: 1000}
+	} else if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	added := scoreHalfLives.contribution(delta, newsTime(in, now), now)
+	projected := scoreVector{
+		float64(current.Safety) + added.safety,
+		float64(current.Economy) + added.economy,
+		float64(current.QualityOfLife) + added.qol,
+		float64(current.Culture) + added.culture,
+	}.toProto(current.City, current.Country, in.Date)
+	preview.Current, preview.Projected = current, projected
+	return preview, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	pb "mi8/proto"
+)
+
+// News received by IngestNews are applied by batches of this size
+const ingestBatchSize = 200
+
+// A news with the same name, source, city and date was already created
+var errDuplicateNews = errors.New("duplicate news")
+
+// validateNews checks a news before creating, updating or ingesting it
+func validateNews(news *pb.News) error {
+	if strings.TrimSpace(news.Name) == "" {
+		return errors.New("name is required")
+	}
+	if news.Date != "" {
+		if _, err := time.Parse(time.RFC3339, news.Date); err != nil {
+			return errors.New("date is not RFC 3339")
+		}
+	}
+	return nil
+}
+
+// newsFingerprint identifies a news by its content, to detect duplicates
+func newsFingerprint(news *pb.News) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		strings.ToLower(strings.TrimSpace(news.Name)),
+		strings.ToLower(news.Source),
+		strings.ToLower(news.City),
+		news.Date,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *server) IngestNews(stream pb.MI8Service_IngestNewsServer) error {
+	summary := &pb.IngestNewsSummary{Errors: []*pb.IngestNewsError{}}
+	reject := func(index int32, err error) {
+		duplicate := errors.Is(err, errDuplicateNews)
+		if duplicate {
+			summary.Duplicates++
+		} else {
+			summary.Rejected++
+		}
+		summary.Errors = append(summary.Errors, &pb.IngestNewsError{Index: index, Error: err.Error(), Duplicate: duplicate})
+	}
+
+	var batch []*pb.News
+	var indexes []int32
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		errs, err := s.repo.IngestNews(batch)
+		for i, index := range indexes {
+			switch {
+			case err != nil:
+				reject(index, err)
+			case errs[i] != nil:
+				reject(index, errs[i])
+			default:
+				summary.Accepted++
+			}
+		}
+		batch, indexes = batch[:0], indexes[:0]
+	}
+
+	for index := int32(0); ; index++ {
+		news, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := validateNews(news); err != nil {
+			reject(index, err)
+			continue
+		}
+		batch = append(batch, news)
+		indexes = append(indexes, index)
+		if len(batch) == ingestBatchSize {
+			flush()
+		}
+	}
+	flush()
+
+	// Validation errors were added before the errors of their batch
+	sort.Slice(summary.Errors, func(i, j int) bool { return summary.Errors[i].Index < summary.Errors[j].Index })
+	return stream.SendAndClose(summary)
+}
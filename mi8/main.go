@@ -2,12 +2,17 @@ package main
 
 import (
     "context"
+    "errors"
     "fmt"
     "log"
     "net"
     "os"
+    "time"
 
     "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/keepalive"
+    "google.golang.org/grpc/status"
     "google.golang.org/grpc/reflection"
     "google.golang.org/protobuf/types/known/emptypb"
     pb "mi8/proto"
@@ -30,29 +35,91 @@ func (s *server) GetLatestNewsInCity(ctx context.Context, in *pb.GetLatestNewsIn
     return &pb.NewsList{News: news}, nil
 }
 
-func (s *server) CreateNews(ctx context.Context, in *pb.News) (*emptypb.Empty, error) {
-    err := s.repo.CreateNews(in)
+func (s *server) CreateNews(ctx context.Context, in *pb.News) (*pb.News, error) {
+    if err := validateNews(in); err != nil { return nil, status.Error(codes.InvalidArgument, err.Error()) }
+    news, err := s.repo.CreateNews(in)
+    if errors.Is(err, errConcurrentChange) { return nil, status.Error(codes.Aborted, err.Error()) }
+    return news, err
+}
+
+func (s *server) GetNews(ctx context.Context, in *pb.GetNewsRequest) (*pb.News, error) {
+    news, err := s.repo.GetNews(in.Id)
+    if errors.Is(err, errNewsNotFound) { return nil, status.Error(codes.NotFound, err.Error()) }
+    if err != nil { return nil, err }
+    return news, nil
+}
+
+func (s *server) UpdateNews(ctx context.Context, in *pb.News) (*pb.News, error) {
+    if in.Id == "" { return nil, status.Error(codes.InvalidArgument, "id is required") }
+    if err := validateNews(in); err != nil { return nil, status.Error(codes.InvalidArgument, err.Error()) }
+    news, err := s.repo.UpdateNews(in)
+    if errors.Is(err, errNewsNotFound) { return nil, status.Error(codes.NotFound, err.Error()) }
+    if errors.Is(err, errConcurrentChange) { return nil, status.Error(codes.Aborted, err.Error()) }
+    if err != nil { return nil, err }
+    return news, nil
+}
+
+func (s *server) DeleteNews(ctx context.Context, in *pb.DeleteNewsRequest) (*emptypb.Empty, error) {
+    err := s.repo.DeleteNews(in.Id)
+    if errors.Is(err, errNewsNotFound) { return nil, status.Error(codes.NotFound, err.Error()) }
+    if errors.Is(err, errConcurrentChange) { return nil, status.Error(codes.Aborted, err.Error()) }
     if err != nil { return nil, err }
     return &emptypb.Empty{}, nil
 }
 
 func (s *server) GetCityScore(ctx context.Context, in *pb.GetCityScoreRequest) (*pb.CityScore, error) {
     score, err := s.repo.GetCityScore(in.City)
+    if errors.Is(err, errCityNotFound) { return nil, status.Error(codes.NotFound, err.Error()) }
     if err != nil { return nil, err }
     return score, nil
 }
 
 func (s *server) GetTopCities(ctx context.Context, in *pb.GetTopCitiesRequest) (*pb.CityScoreList, error) {
-    scores, err := s.repo.GetTopCities(int(in.Limit))
+    ranking, err := rankingOf(in)
+    if err != nil { return nil, status.Error(codes.InvalidArgument, err.Error()) }
+    scores, err := s.repo.GetTopCities(ranking, int(in.Limit))
     if err != nil { return nil, err }
     return &pb.CityScoreList{Scores: scores}, nil
 }
 
+func (s *server) SubscribeNews(in *pb.SubscribeNewsRequest, stream pb.MI8Service_SubscribeNewsServer) error {
+    sub, err := s.repo.SubscribeNews(in.ResumeFrom)
+    if errors.Is(err, errInvalidResumeID) { return status.Error(codes.InvalidArgument, err.Error()) }
+    if errors.Is(err, errResumeExpired) { return status.Error(codes.OutOfRange, err.Error()) }
+    if err != nil { return err }
+    defer sub.Close()
+
+    // Id of the last event seen, filtered out or not, for the client to resume after
+    lastID := in.ResumeFrom
+    for {
+        event, err := sub.Next(stream.Context())
+        if errors.Is(err, errSlowSubscriber) {
+            return status.Errorf(codes.ResourceExhausted, "%v, resume from %q", err, lastID)
+        }
+        if errors.Is(err, errResumeExpired) { return status.Error(codes.OutOfRange, err.Error()) }
+        if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+            return status.FromContextError(err).Err()
+        }
+        if err != nil { return err }
+
+        lastID = event.Id
+        if !matchesSubscription(in, event.News) { continue }
+        if err := stream.Send(event); err != nil { return err }
+    }
+}
+
 func main() {
     lis, err := net.Listen("tcp", ":50051")
     if err != nil { log.Fatalf("failed to listen: %v", err) }
-    s := grpc.NewServer()
+    // Clients like polytech keep their connection alive with pings every 30s, even when idle
+    s := grpc.NewServer(
+        grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: 20 * time.Second, PermitWithoutStream: true}),
+        grpc.KeepaliveParams(keepalive.ServerParameters{Time: time.Minute, Timeout: 10 * time.Second}),
+    )
     
+    if err := loadHalfLives(); err != nil { log.Fatalf("invalid score decay: %v", err) }
+    if v, err := time.ParseDuration(os.Getenv("SCORE_HISTORY_RETENTION")); err == nil && v > 0 { scoreHistoryRetention = v }
+
     // Initialize Repository (Redis)
     var repo NewsRepository
     redisAddr := os.Getenv("REDIS_ADDR")
@@ -60,12 +127,24 @@ func main() {
         fmt.Println("Using Redis Repository at " + redisAddr)
         r, err := NewRedisNewsRepository()
         if err != nil { log.Fatalf("failed to create redis repo: %v", err) }
+        // Rankings follow the decay of the scores
+        interval := 10 * time.Minute
+        if v, err := time.ParseDuration(os.Getenv("SCORE_REFRESH_INTERVAL")); err == nil && v > 0 { interval = v }
+        go r.refreshRankings(interval)
         repo = r
     } else {
         fmt.Println("Using In-Memory Repository (Default)")
         repo = NewArrayNewsRepository()
     }
     
+    // Impact rules, reloaded from their file when it changes
+    if rulesFile := os.Getenv("IMPACT_RULES_FILE"); rulesFile != "" {
+        if err := impactRules.load(rulesFile); err != nil { log.Fatalf("failed to load impact rules: %v", err) }
+        interval := 10 * time.Second
+        if v, err := time.ParseDuration(os.Getenv("IMPACT_RULES_RELOAD")); err == nil && v > 0 { interval = v }
+        go impactRules.watch(rulesFile, interval)
+    }
+
     pb.RegisterMI8ServiceServer(s, &server{repo: repo})
     reflection.Register(s)
 
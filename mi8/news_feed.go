@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	pb "mi8/proto"
+)
+
+var (
+	// The subscriber didn't keep up with created news and was dropped, it may resume from its last event
+	errSlowSubscriber = errors.New("subscriber is too slow")
+	// The news following the resume id are no longer kept
+	errResumeExpired   = errors.New("news to resume from are no longer available")
+	errInvalidResumeID = errors.New("invalid resume id")
+)
+
+// NewsSubscription delivers the created news, in creation order
+type NewsSubscription interface {
+	// Next blocks until a news is created or ctx is done
+	Next(ctx context.Context) (*pb.NewsEvent, error)
+	Close()
+}
+
+// matchesSubscription tells whether a news passes the filters of a subscription
+func matchesSubscription(in *pb.SubscribeNewsRequest, news *pb.News) bool {
+	if in.City != "" && !strings.EqualFold(in.City, news.City) {
+		return false
+	}
+	if in.Country != "" && !strings.EqualFold(in.Country, news.Country) {
+		return false
+	}
+	if len(in.Tags) == 0 {
+		return true
+	}
+	for _, want := range in.Tags {
+		for _, tag := range news.Tags {
+			if strings.EqualFold(want, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+const (
+	// Events kept to resume subscriptions
+	newsHubHistory = 1024
+	// Events waiting to be sent to a subscriber before it is dropped
+	subscriberBuffer = 256
+)
+
+// newsHub fans out the news created in process to subscribers, or the entries of the Redis
+// stream read by the process (see PublishEvent). Each subscriber has a bounded buffer so a slow
+// one can't hold back CreateNews: when it is full the subscriber is dropped.
+type newsHub struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     [newsHubHistory]*pb.NewsEvent // ring buffer, event n is at n % newsHubHistory
+	lastEventID string                        // id of the last event given to subscribers
+	subscribers map[*hubSubscription]struct{}
+}
+
+func newNewsHub() *newsHub {
+	return &newsHub{subscribers: map[*hubSubscription]struct{}{}}
+}
+
+func (h *newsHub) Publish(news *pb.News) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	event := &pb.NewsEvent{Id: strconv.FormatUint(h.lastID, 10), News: news}
+	h.history[h.lastID%newsHubHistory] = event
+	h.send(event)
+}
+
+// PublishEvent fans out an event with its own id, an entry of the Redis stream. These events
+// aren't kept to resume from, the stream is.
+func (h *newsHub) PublishEvent(event *pb.NewsEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.send(event)
+}
+
+// send gives the event to every subscriber, dropping those whose buffer is full. h.mu is held.
+func (h *newsHub) send(event *pb.NewsEvent) {
+	h.lastEventID = event.Id
+	for sub := range h.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			delete(h.subscribers, sub)
+			close(sub.dropped)
+		}
+	}
+}
+
+// Subscribe starts after resumeFrom, or with the next created news when it is empty
+func (h *newsHub) Subscribe(resumeFrom string) (NewsSubscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	from := h.lastID
+	if resumeFrom != "" {
+		id, err := strconv.ParseUint(resumeFrom, 10, 64)
+		if err != nil || id > h.lastID {
+			return nil, errInvalidResumeID
+		}
+		if h.lastID-id > newsHubHistory {
+			return nil, errResumeExpired
+		}
+		from = id
+	}
+
+	sub := h.subscribe()
+	for id := from + 1; id <= h.lastID; id++ {
+		sub.backlog = append(sub.backlog, h.history[id%newsHubHistory])
+	}
+	return sub, nil
+}
+
+// SubscribeLive starts with the next published event, and tells the id of the last one
+func (h *newsHub) SubscribeLive() (*hubSubscription, string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.subscribe(), h.lastEventID
+}
+
+// subscribe adds a subscriber. h.mu is held.
+func (h *newsHub) subscribe() *hubSubscription {
+	sub := &hubSubscription{
+		hub:     h,
+		events:  make(chan *pb.NewsEvent, subscriberBuffer),
+		dropped: make(chan struct{}),
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub
+}
+
+type hubSubscription struct {
+	hub *newsHub
+	// Events created before subscribing, sent first
+	backlog []*pb.NewsEvent
+	events  chan *pb.NewsEvent
+	// Closed when the hub drops the subscriber
+	dropped chan struct{}
+}
+
+func (s *hubSubscription) Next(ctx context.Context) (*pb.NewsEvent, error) {
+	if len(s.backlog) > 0 {
+		event := s.backlog[0]
+		s.backlog = s.backlog[1:]
+		return event, nil
+	}
+	select {
+	case event := <-s.events:
+		return event, nil
+	case <-s.dropped:
+		return nil, errSlowSubscriber
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *hubSubscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	delete(s.hub.subscribers, s)
+}
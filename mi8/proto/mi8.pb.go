@@ -22,6 +22,162 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ScoreDimension int32
+
+const (
+	ScoreDimension_TOTAL           ScoreDimension = 0
+	ScoreDimension_SAFETY          ScoreDimension = 1
+	ScoreDimension_ECONOMY         ScoreDimension = 2
+	ScoreDimension_QUALITY_OF_LIFE ScoreDimension = 3
+	ScoreDimension_CULTURE         ScoreDimension = 4
+)
+
+// Enum value maps for ScoreDimension.
+var (
+	ScoreDimension_name = map[int32]string{
+		0: "TOTAL",
+		1: "SAFETY",
+		2: "ECONOMY",
+		3: "QUALITY_OF_LIFE",
+		4: "CULTURE",
+	}
+	ScoreDimension_value = map[string]int32{
+		"TOTAL":           0,
+		"SAFETY":          1,
+		"ECONOMY":         2,
+		"QUALITY_OF_LIFE": 3,
+		"CULTURE":         4,
+	}
+)
+
+func (x ScoreDimension) Enum() *ScoreDimension {
+	p := new(ScoreDimension)
+	*p = x
+	return p
+}
+
+func (x ScoreDimension) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScoreDimension) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_mi8_proto_enumTypes[0].Descriptor()
+}
+
+func (ScoreDimension) Type() protoreflect.EnumType {
+	return &file_proto_mi8_proto_enumTypes[0]
+}
+
+func (x ScoreDimension) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScoreDimension.Descriptor instead.
+func (ScoreDimension) EnumDescriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{0}
+}
+
+type HistoryBucket int32
+
+const (
+	HistoryBucket_DAY   HistoryBucket = 0
+	HistoryBucket_WEEK  HistoryBucket = 1
+	HistoryBucket_MONTH HistoryBucket = 2
+)
+
+// Enum value maps for HistoryBucket.
+var (
+	HistoryBucket_name = map[int32]string{
+		0: "DAY",
+		1: "WEEK",
+		2: "MONTH",
+	}
+	HistoryBucket_value = map[string]int32{
+		"DAY":   0,
+		"WEEK":  1,
+		"MONTH": 2,
+	}
+)
+
+func (x HistoryBucket) Enum() *HistoryBucket {
+	p := new(HistoryBucket)
+	*p = x
+	return p
+}
+
+func (x HistoryBucket) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HistoryBucket) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_mi8_proto_enumTypes[1].Descriptor()
+}
+
+func (HistoryBucket) Type() protoreflect.EnumType {
+	return &file_proto_mi8_proto_enumTypes[1]
+}
+
+func (x HistoryBucket) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HistoryBucket.Descriptor instead.
+func (HistoryBucket) EnumDescriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{1}
+}
+
+type Trend int32
+
+const (
+	Trend_TREND_UNKNOWN   Trend = 0
+	Trend_TREND_IMPROVING Trend = 1
+	Trend_TREND_STABLE    Trend = 2
+	Trend_TREND_DECLINING Trend = 3
+)
+
+// Enum value maps for Trend.
+var (
+	Trend_name = map[int32]string{
+		0: "TREND_UNKNOWN",
+		1: "TREND_IMPROVING",
+		2: "TREND_STABLE",
+		3: "TREND_DECLINING",
+	}
+	Trend_value = map[string]int32{
+		"TREND_UNKNOWN":   0,
+		"TREND_IMPROVING": 1,
+		"TREND_STABLE":    2,
+		"TREND_DECLINING": 3,
+	}
+)
+
+func (x Trend) Enum() *Trend {
+	p := new(Trend)
+	*p = x
+	return p
+}
+
+func (x Trend) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Trend) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_mi8_proto_enumTypes[2].Descriptor()
+}
+
+func (Trend) Type() protoreflect.EnumType {
+	return &file_proto_mi8_proto_enumTypes[2]
+}
+
+func (x Trend) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Trend.Descriptor instead.
+func (Trend) EnumDescriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{2}
+}
+
 type News struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -30,6 +186,7 @@ type News struct {
 	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
 	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
 	Country       string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Id            string                 `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,6 +263,101 @@ func (x *News) GetCountry() string {
 	return ""
 }
 
+func (x *News) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetNewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNewsRequest) Reset() {
+	*x = GetNewsRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNewsRequest) ProtoMessage() {}
+
+func (x *GetNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNewsRequest.ProtoReflect.Descriptor instead.
+func (*GetNewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetNewsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteNewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNewsRequest) Reset() {
+	*x = DeleteNewsRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNewsRequest) ProtoMessage() {}
+
+func (x *DeleteNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNewsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeleteNewsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type GetLatestNewsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
@@ -115,7 +367,7 @@ type GetLatestNewsRequest struct {
 
 func (x *GetLatestNewsRequest) Reset() {
 	*x = GetLatestNewsRequest{}
-	mi := &file_proto_mi8_proto_msgTypes[1]
+	mi := &file_proto_mi8_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -127,7 +379,7 @@ func (x *GetLatestNewsRequest) String() string {
 func (*GetLatestNewsRequest) ProtoMessage() {}
 
 func (x *GetLatestNewsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[1]
+	mi := &file_proto_mi8_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -140,7 +392,7 @@ func (x *GetLatestNewsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestNewsRequest.ProtoReflect.Descriptor instead.
 func (*GetLatestNewsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{1}
+	return file_proto_mi8_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetLatestNewsRequest) GetLimit() int32 {
@@ -160,7 +412,7 @@ type GetLatestNewsInCityRequest struct {
 
 func (x *GetLatestNewsInCityRequest) Reset() {
 	*x = GetLatestNewsInCityRequest{}
-	mi := &file_proto_mi8_proto_msgTypes[2]
+	mi := &file_proto_mi8_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -172,7 +424,7 @@ func (x *GetLatestNewsInCityRequest) String() string {
 func (*GetLatestNewsInCityRequest) ProtoMessage() {}
 
 func (x *GetLatestNewsInCityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[2]
+	mi := &file_proto_mi8_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -185,7 +437,7 @@ func (x *GetLatestNewsInCityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestNewsInCityRequest.ProtoReflect.Descriptor instead.
 func (*GetLatestNewsInCityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{2}
+	return file_proto_mi8_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetLatestNewsInCityRequest) GetCity() string {
@@ -211,7 +463,7 @@ type NewsList struct {
 
 func (x *NewsList) Reset() {
 	*x = NewsList{}
-	mi := &file_proto_mi8_proto_msgTypes[3]
+	mi := &file_proto_mi8_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -223,7 +475,7 @@ func (x *NewsList) String() string {
 func (*NewsList) ProtoMessage() {}
 
 func (x *NewsList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[3]
+	mi := &file_proto_mi8_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -236,7 +488,7 @@ func (x *NewsList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewsList.ProtoReflect.Descriptor instead.
 func (*NewsList) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{3}
+	return file_proto_mi8_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NewsList) GetNews() []*News {
@@ -255,7 +507,7 @@ type GetCityScoreRequest struct {
 
 func (x *GetCityScoreRequest) Reset() {
 	*x = GetCityScoreRequest{}
-	mi := &file_proto_mi8_proto_msgTypes[4]
+	mi := &file_proto_mi8_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -267,7 +519,7 @@ func (x *GetCityScoreRequest) String() string {
 func (*GetCityScoreRequest) ProtoMessage() {}
 
 func (x *GetCityScoreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[4]
+	mi := &file_proto_mi8_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,7 +532,7 @@ func (x *GetCityScoreRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCityScoreRequest.ProtoReflect.Descriptor instead.
 func (*GetCityScoreRequest) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{4}
+	return file_proto_mi8_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetCityScoreRequest) GetCity() string {
@@ -291,15 +543,21 @@ func (x *GetCityScoreRequest) GetCity() string {
 }
 
 type GetTopCitiesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Ignored when weights are set
+	Dimension ScoreDimension `protobuf:"varint,2,opt,name=dimension,proto3,enum=mi8.ScoreDimension" json:"dimension,omitempty"`
+	// Ranks by the weighted sum of the dimensions
+	Weights *ScoreWeights `protobuf:"bytes,3,opt,name=weights,proto3" json:"weights,omitempty"`
+	// Only the cities of this country when set
+	Country       string `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTopCitiesRequest) Reset() {
 	*x = GetTopCitiesRequest{}
-	mi := &file_proto_mi8_proto_msgTypes[5]
+	mi := &file_proto_mi8_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -311,7 +569,7 @@ func (x *GetTopCitiesRequest) String() string {
 func (*GetTopCitiesRequest) ProtoMessage() {}
 
 func (x *GetTopCitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[5]
+	mi := &file_proto_mi8_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,7 +582,7 @@ func (x *GetTopCitiesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopCitiesRequest.ProtoReflect.Descriptor instead.
 func (*GetTopCitiesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{5}
+	return file_proto_mi8_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetTopCitiesRequest) GetLimit() int32 {
@@ -334,34 +592,49 @@ func (x *GetTopCitiesRequest) GetLimit() int32 {
 	return 0
 }
 
-type CityScore struct {
+func (x *GetTopCitiesRequest) GetDimension() ScoreDimension {
+	if x != nil {
+		return x.Dimension
+	}
+	return ScoreDimension_TOTAL
+}
+
+func (x *GetTopCitiesRequest) GetWeights() *ScoreWeights {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *GetTopCitiesRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type GetCountryScoreRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
-	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
-	QualityOfLife int32                  `protobuf:"varint,3,opt,name=quality_of_life,json=qualityOfLife,proto3" json:"quality_of_life,omitempty"`
-	Safety        int32                  `protobuf:"varint,4,opt,name=safety,proto3" json:"safety,omitempty"`
-	Economy       int32                  `protobuf:"varint,5,opt,name=economy,proto3" json:"economy,omitempty"`
-	Culture       int32                  `protobuf:"varint,6,opt,name=culture,proto3" json:"culture,omitempty"`
-	LastUpdated   string                 `protobuf:"bytes,7,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CityScore) Reset() {
-	*x = CityScore{}
-	mi := &file_proto_mi8_proto_msgTypes[6]
+func (x *GetCountryScoreRequest) Reset() {
+	*x = GetCountryScoreRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CityScore) String() string {
+func (x *GetCountryScoreRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CityScore) ProtoMessage() {}
+func (*GetCountryScoreRequest) ProtoMessage() {}
 
-func (x *CityScore) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[6]
+func (x *GetCountryScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -372,82 +645,94 @@ func (x *CityScore) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CityScore.ProtoReflect.Descriptor instead.
-func (*CityScore) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetCountryScoreRequest.ProtoReflect.Descriptor instead.
+func (*GetCountryScoreRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CityScore) GetCity() string {
+func (x *GetCountryScoreRequest) GetCountry() string {
 	if x != nil {
-		return x.City
+		return x.Country
 	}
 	return ""
 }
 
-func (x *CityScore) GetCountry() string {
-	if x != nil {
-		return x.Country
-	}
-	return ""
+type GetTopCountriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Ranks by the mean of this dimension
+	Dimension     ScoreDimension `protobuf:"varint,2,opt,name=dimension,proto3,enum=mi8.ScoreDimension" json:"dimension,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CityScore) GetQualityOfLife() int32 {
-	if x != nil {
-		return x.QualityOfLife
-	}
-	return 0
+func (x *GetTopCountriesRequest) Reset() {
+	*x = GetTopCountriesRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CityScore) GetSafety() int32 {
-	if x != nil {
-		return x.Safety
-	}
-	return 0
+func (x *GetTopCountriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *CityScore) GetEconomy() int32 {
+func (*GetTopCountriesRequest) ProtoMessage() {}
+
+func (x *GetTopCountriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[9]
 	if x != nil {
-		return x.Economy
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CityScore) GetCulture() int32 {
+// Deprecated: Use GetTopCountriesRequest.ProtoReflect.Descriptor instead.
+func (*GetTopCountriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTopCountriesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Culture
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *CityScore) GetLastUpdated() string {
+func (x *GetTopCountriesRequest) GetDimension() ScoreDimension {
 	if x != nil {
-		return x.LastUpdated
+		return x.Dimension
 	}
-	return ""
+	return ScoreDimension_TOTAL
 }
 
-type CityScoreList struct {
+type ListCitiesInCountryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Scores        []*CityScore           `protobuf:"bytes,1,rep,name=scores,proto3" json:"scores,omitempty"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CityScoreList) Reset() {
-	*x = CityScoreList{}
-	mi := &file_proto_mi8_proto_msgTypes[7]
+func (x *ListCitiesInCountryRequest) Reset() {
+	*x = ListCitiesInCountryRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CityScoreList) String() string {
+func (x *ListCitiesInCountryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CityScoreList) ProtoMessage() {}
+func (*ListCitiesInCountryRequest) ProtoMessage() {}
 
-func (x *CityScoreList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[7]
+func (x *ListCitiesInCountryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,30 +743,1351 @@ func (x *CityScoreList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CityScoreList.ProtoReflect.Descriptor instead.
-func (*CityScoreList) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListCitiesInCountryRequest.ProtoReflect.Descriptor instead.
+func (*ListCitiesInCountryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListCitiesInCountryRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ListCitiesInCountryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Scores of the cities of a country, as read, aggregated per dimension
+type DimensionScores struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Safety        float64                `protobuf:"fixed64,1,opt,name=safety,proto3" json:"safety,omitempty"`
+	Economy       float64                `protobuf:"fixed64,2,opt,name=economy,proto3" json:"economy,omitempty"`
+	QualityOfLife float64                `protobuf:"fixed64,3,opt,name=quality_of_life,json=qualityOfLife,proto3" json:"quality_of_life,omitempty"`
+	Culture       float64                `protobuf:"fixed64,4,opt,name=culture,proto3" json:"culture,omitempty"`
+	Total         float64                `protobuf:"fixed64,5,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DimensionScores) Reset() {
+	*x = DimensionScores{}
+	mi := &file_proto_mi8_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DimensionScores) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DimensionScores) ProtoMessage() {}
+
+func (x *DimensionScores) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DimensionScores.ProtoReflect.Descriptor instead.
+func (*DimensionScores) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DimensionScores) GetSafety() float64 {
+	if x != nil {
+		return x.Safety
+	}
+	return 0
+}
+
+func (x *DimensionScores) GetEconomy() float64 {
+	if x != nil {
+		return x.Economy
+	}
+	return 0
+}
+
+func (x *DimensionScores) GetQualityOfLife() float64 {
+	if x != nil {
+		return x.QualityOfLife
+	}
+	return 0
+}
+
+func (x *DimensionScores) GetCulture() float64 {
+	if x != nil {
+		return x.Culture
+	}
+	return 0
+}
+
+func (x *DimensionScores) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CountryScore struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Country   string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	CityCount int32                  `protobuf:"varint,2,opt,name=city_count,json=cityCount,proto3" json:"city_count,omitempty"`
+	Mean      *DimensionScores       `protobuf:"bytes,3,opt,name=mean,proto3" json:"mean,omitempty"`
+	Median    *DimensionScores       `protobuf:"bytes,4,opt,name=median,proto3" json:"median,omitempty"`
+	// Cities with the lowest and highest total
+	MinCity       *CityScore `protobuf:"bytes,5,opt,name=min_city,json=minCity,proto3" json:"min_city,omitempty"`
+	MaxCity       *CityScore `protobuf:"bytes,6,opt,name=max_city,json=maxCity,proto3" json:"max_city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountryScore) Reset() {
+	*x = CountryScore{}
+	mi := &file_proto_mi8_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountryScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryScore) ProtoMessage() {}
+
+func (x *CountryScore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryScore.ProtoReflect.Descriptor instead.
+func (*CountryScore) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CountryScore) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CountryScore) GetCityCount() int32 {
+	if x != nil {
+		return x.CityCount
+	}
+	return 0
+}
+
+func (x *CountryScore) GetMean() *DimensionScores {
+	if x != nil {
+		return x.Mean
+	}
+	return nil
+}
+
+func (x *CountryScore) GetMedian() *DimensionScores {
+	if x != nil {
+		return x.Median
+	}
+	return nil
+}
+
+func (x *CountryScore) GetMinCity() *CityScore {
+	if x != nil {
+		return x.MinCity
+	}
+	return nil
+}
+
+func (x *CountryScore) GetMaxCity() *CityScore {
+	if x != nil {
+		return x.MaxCity
+	}
+	return nil
+}
+
+type CountryScoreList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scores        []*CountryScore        `protobuf:"bytes,1,rep,name=scores,proto3" json:"scores,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountryScoreList) Reset() {
+	*x = CountryScoreList{}
+	mi := &file_proto_mi8_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountryScoreList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryScoreList) ProtoMessage() {}
+
+func (x *CountryScoreList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryScoreList.ProtoReflect.Descriptor instead.
+func (*CountryScoreList) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CountryScoreList) GetScores() []*CountryScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+// Weights are at least 0, one of them above
+type ScoreWeights struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Safety        float64                `protobuf:"fixed64,1,opt,name=safety,proto3" json:"safety,omitempty"`
+	Economy       float64                `protobuf:"fixed64,2,opt,name=economy,proto3" json:"economy,omitempty"`
+	QualityOfLife float64                `protobuf:"fixed64,3,opt,name=quality_of_life,json=qualityOfLife,proto3" json:"quality_of_life,omitempty"`
+	Culture       float64                `protobuf:"fixed64,4,opt,name=culture,proto3" json:"culture,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreWeights) Reset() {
+	*x = ScoreWeights{}
+	mi := &file_proto_mi8_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreWeights) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreWeights) ProtoMessage() {}
+
+func (x *ScoreWeights) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreWeights.ProtoReflect.Descriptor instead.
+func (*ScoreWeights) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ScoreWeights) GetSafety() float64 {
+	if x != nil {
+		return x.Safety
+	}
+	return 0
+}
+
+func (x *ScoreWeights) GetEconomy() float64 {
+	if x != nil {
+		return x.Economy
+	}
+	return 0
+}
+
+func (x *ScoreWeights) GetQualityOfLife() float64 {
+	if x != nil {
+		return x.QualityOfLife
+	}
+	return 0
+}
+
+func (x *ScoreWeights) GetCulture() float64 {
+	if x != nil {
+		return x.Culture
+	}
+	return 0
+}
+
+type CityScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	QualityOfLife int32                  `protobuf:"varint,3,opt,name=quality_of_life,json=qualityOfLife,proto3" json:"quality_of_life,omitempty"`
+	Safety        int32                  `protobuf:"varint,4,opt,name=safety,proto3" json:"safety,omitempty"`
+	Economy       int32                  `protobuf:"varint,5,opt,name=economy,proto3" json:"economy,omitempty"`
+	Culture       int32                  `protobuf:"varint,6,opt,name=culture,proto3" json:"culture,omitempty"`
+	LastUpdated   string                 `protobuf:"bytes,7,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CityScore) Reset() {
+	*x = CityScore{}
+	mi := &file_proto_mi8_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CityScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityScore) ProtoMessage() {}
+
+func (x *CityScore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityScore.ProtoReflect.Descriptor instead.
+func (*CityScore) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CityScore) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CityScore) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CityScore) GetQualityOfLife() int32 {
+	if x != nil {
+		return x.QualityOfLife
+	}
+	return 0
+}
+
+func (x *CityScore) GetSafety() int32 {
+	if x != nil {
+		return x.Safety
+	}
+	return 0
+}
+
+func (x *CityScore) GetEconomy() int32 {
+	if x != nil {
+		return x.Economy
+	}
+	return 0
+}
+
+func (x *CityScore) GetCulture() int32 {
+	if x != nil {
+		return x.Culture
+	}
+	return 0
+}
+
+func (x *CityScore) GetLastUpdated() string {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return ""
+}
+
+type CityScoreList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scores        []*CityScore           `protobuf:"bytes,1,rep,name=scores,proto3" json:"scores,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CityScoreList) Reset() {
+	*x = CityScoreList{}
+	mi := &file_proto_mi8_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CityScoreList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityScoreList) ProtoMessage() {}
+
+func (x *CityScoreList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityScoreList.ProtoReflect.Descriptor instead.
+func (*CityScoreList) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CityScoreList) GetScores() []*CityScore {
 	if x != nil {
-		return x.Scores
+		return x.Scores
+	}
+	return nil
+}
+
+type SubscribeNewsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filters, empty means any. A news matches if it has at least one of the tags.
+	City    string   `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Country string   `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Tags    []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Id of the last event received, to resume a subscription without missing news
+	ResumeFrom    string `protobuf:"bytes,4,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeNewsRequest) Reset() {
+	*x = SubscribeNewsRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeNewsRequest) ProtoMessage() {}
+
+func (x *SubscribeNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeNewsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeNewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SubscribeNewsRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *SubscribeNewsRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SubscribeNewsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SubscribeNewsRequest) GetResumeFrom() string {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return ""
+}
+
+type NewsEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	News          *News                  `protobuf:"bytes,2,opt,name=news,proto3" json:"news,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewsEvent) Reset() {
+	*x = NewsEvent{}
+	mi := &file_proto_mi8_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewsEvent) ProtoMessage() {}
+
+func (x *NewsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewsEvent.ProtoReflect.Descriptor instead.
+func (*NewsEvent) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NewsEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NewsEvent) GetNews() *News {
+	if x != nil {
+		return x.News
+	}
+	return nil
+}
+
+type IngestNewsSummary struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Accepted   int32                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected   int32                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Duplicates int32                  `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	// Rejected and duplicate news
+	Errors        []*IngestNewsError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestNewsSummary) Reset() {
+	*x = IngestNewsSummary{}
+	mi := &file_proto_mi8_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestNewsSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestNewsSummary) ProtoMessage() {}
+
+func (x *IngestNewsSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestNewsSummary.ProtoReflect.Descriptor instead.
+func (*IngestNewsSummary) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *IngestNewsSummary) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *IngestNewsSummary) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *IngestNewsSummary) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *IngestNewsSummary) GetErrors() []*IngestNewsError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type IngestNewsError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the news in the stream, from 0
+	Index         int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Duplicate     bool   `protobuf:"varint,3,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestNewsError) Reset() {
+	*x = IngestNewsError{}
+	mi := &file_proto_mi8_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestNewsError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestNewsError) ProtoMessage() {}
+
+func (x *IngestNewsError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestNewsError.ProtoReflect.Descriptor instead.
+func (*IngestNewsError) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IngestNewsError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IngestNewsError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *IngestNewsError) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type ScoreDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Safety        int32                  `protobuf:"varint,1,opt,name=safety,proto3" json:"safety,omitempty"`
+	Economy       int32                  `protobuf:"varint,2,opt,name=economy,proto3" json:"economy,omitempty"`
+	QualityOfLife int32                  `protobuf:"varint,3,opt,name=quality_of_life,json=qualityOfLife,proto3" json:"quality_of_life,omitempty"`
+	Culture       int32                  `protobuf:"varint,4,opt,name=culture,proto3" json:"culture,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreDelta) Reset() {
+	*x = ScoreDelta{}
+	mi := &file_proto_mi8_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreDelta) ProtoMessage() {}
+
+func (x *ScoreDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreDelta.ProtoReflect.Descriptor instead.
+func (*ScoreDelta) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ScoreDelta) GetSafety() int32 {
+	if x != nil {
+		return x.Safety
+	}
+	return 0
+}
+
+func (x *ScoreDelta) GetEconomy() int32 {
+	if x != nil {
+		return x.Economy
+	}
+	return 0
+}
+
+func (x *ScoreDelta) GetQualityOfLife() int32 {
+	if x != nil {
+		return x.QualityOfLife
+	}
+	return 0
+}
+
+func (x *ScoreDelta) GetCulture() int32 {
+	if x != nil {
+		return x.Culture
+	}
+	return 0
+}
+
+type ImpactRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tag   string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Other tags having the same impact
+	Synonyms      []string    `protobuf:"bytes,2,rep,name=synonyms,proto3" json:"synonyms,omitempty"`
+	Impact        *ScoreDelta `protobuf:"bytes,3,opt,name=impact,proto3" json:"impact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpactRule) Reset() {
+	*x = ImpactRule{}
+	mi := &file_proto_mi8_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpactRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpactRule) ProtoMessage() {}
+
+func (x *ImpactRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpactRule.ProtoReflect.Descriptor instead.
+func (*ImpactRule) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ImpactRule) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ImpactRule) GetSynonyms() []string {
+	if x != nil {
+		return x.Synonyms
+	}
+	return nil
+}
+
+func (x *ImpactRule) GetImpact() *ScoreDelta {
+	if x != nil {
+		return x.Impact
+	}
+	return nil
+}
+
+type ImpactRuleSet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Incremented on every change
+	Version       int64         `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt     string        `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Rules         []*ImpactRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpactRuleSet) Reset() {
+	*x = ImpactRuleSet{}
+	mi := &file_proto_mi8_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpactRuleSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpactRuleSet) ProtoMessage() {}
+
+func (x *ImpactRuleSet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpactRuleSet.ProtoReflect.Descriptor instead.
+func (*ImpactRuleSet) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ImpactRuleSet) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ImpactRuleSet) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *ImpactRuleSet) GetRules() []*ImpactRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type ListImpactRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImpactRulesRequest) Reset() {
+	*x = ListImpactRulesRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImpactRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImpactRulesRequest) ProtoMessage() {}
+
+func (x *ListImpactRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImpactRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListImpactRulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{24}
+}
+
+type PutImpactRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rule  *ImpactRule            `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Fail unless the rules are at this version, 0 to skip the check
+	ExpectedVersion int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PutImpactRuleRequest) Reset() {
+	*x = PutImpactRuleRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutImpactRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutImpactRuleRequest) ProtoMessage() {}
+
+func (x *PutImpactRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutImpactRuleRequest.ProtoReflect.Descriptor instead.
+func (*PutImpactRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PutImpactRuleRequest) GetRule() *ImpactRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *PutImpactRuleRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteImpactRuleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Tag             string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteImpactRuleRequest) Reset() {
+	*x = DeleteImpactRuleRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteImpactRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteImpactRuleRequest) ProtoMessage() {}
+
+func (x *DeleteImpactRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteImpactRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteImpactRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteImpactRuleRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *DeleteImpactRuleRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ImpactPreview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RulesVersion  int64                  `protobuf:"varint,1,opt,name=rules_version,json=rulesVersion,proto3" json:"rules_version,omitempty"`
+	MatchedTags   []string               `protobuf:"bytes,2,rep,name=matched_tags,json=matchedTags,proto3" json:"matched_tags,omitempty"`
+	UnmatchedTags []string               `protobuf:"bytes,3,rep,name=unmatched_tags,json=unmatchedTags,proto3" json:"unmatched_tags,omitempty"`
+	Impact        *ScoreDelta            `protobuf:"bytes,4,opt,name=impact,proto3" json:"impact,omitempty"`
+	Current       *CityScore             `protobuf:"bytes,5,opt,name=current,proto3" json:"current,omitempty"`
+	Projected     *CityScore             `protobuf:"bytes,6,opt,name=projected,proto3" json:"projected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpactPreview) Reset() {
+	*x = ImpactPreview{}
+	mi := &file_proto_mi8_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpactPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpactPreview) ProtoMessage() {}
+
+func (x *ImpactPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpactPreview.ProtoReflect.Descriptor instead.
+func (*ImpactPreview) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ImpactPreview) GetRulesVersion() int64 {
+	if x != nil {
+		return x.RulesVersion
+	}
+	return 0
+}
+
+func (x *ImpactPreview) GetMatchedTags() []string {
+	if x != nil {
+		return x.MatchedTags
+	}
+	return nil
+}
+
+func (x *ImpactPreview) GetUnmatchedTags() []string {
+	if x != nil {
+		return x.UnmatchedTags
+	}
+	return nil
+}
+
+func (x *ImpactPreview) GetImpact() *ScoreDelta {
+	if x != nil {
+		return x.Impact
+	}
+	return nil
+}
+
+func (x *ImpactPreview) GetCurrent() *CityScore {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *ImpactPreview) GetProjected() *CityScore {
+	if x != nil {
+		return x.Projected
+	}
+	return nil
+}
+
+type GetCityScoreHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	City  string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// RFC 3339, to defaults to now and from to 30 buckets before
+	From          string        `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string        `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Bucket        HistoryBucket `protobuf:"varint,4,opt,name=bucket,proto3,enum=mi8.HistoryBucket" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCityScoreHistoryRequest) Reset() {
+	*x = GetCityScoreHistoryRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCityScoreHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCityScoreHistoryRequest) ProtoMessage() {}
+
+func (x *GetCityScoreHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCityScoreHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCityScoreHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetCityScoreHistoryRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetCityScoreHistoryRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetCityScoreHistoryRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetCityScoreHistoryRequest) GetBucket() HistoryBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return HistoryBucket_DAY
+}
+
+// Mean of the scores recorded in a bucket
+type CityScorePoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	QualityOfLife int32                  `protobuf:"varint,2,opt,name=quality_of_life,json=qualityOfLife,proto3" json:"quality_of_life,omitempty"`
+	Safety        int32                  `protobuf:"varint,3,opt,name=safety,proto3" json:"safety,omitempty"`
+	Economy       int32                  `protobuf:"varint,4,opt,name=economy,proto3" json:"economy,omitempty"`
+	Culture       int32                  `protobuf:"varint,5,opt,name=culture,proto3" json:"culture,omitempty"`
+	Total         int32                  `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	Samples       int32                  `protobuf:"varint,7,opt,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CityScorePoint) Reset() {
+	*x = CityScorePoint{}
+	mi := &file_proto_mi8_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CityScorePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityScorePoint) ProtoMessage() {}
+
+func (x *CityScorePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityScorePoint.ProtoReflect.Descriptor instead.
+func (*CityScorePoint) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CityScorePoint) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *CityScorePoint) GetQualityOfLife() int32 {
+	if x != nil {
+		return x.QualityOfLife
+	}
+	return 0
+}
+
+func (x *CityScorePoint) GetSafety() int32 {
+	if x != nil {
+		return x.Safety
+	}
+	return 0
+}
+
+func (x *CityScorePoint) GetEconomy() int32 {
+	if x != nil {
+		return x.Economy
+	}
+	return 0
+}
+
+func (x *CityScorePoint) GetCulture() int32 {
+	if x != nil {
+		return x.Culture
+	}
+	return 0
+}
+
+func (x *CityScorePoint) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CityScorePoint) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+type CityScoreHistory struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	City   string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Bucket HistoryBucket          `protobuf:"varint,2,opt,name=bucket,proto3,enum=mi8.HistoryBucket" json:"bucket,omitempty"`
+	Points []*CityScorePoint      `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`
+	// Direction of the total over the points
+	Trend         Trend `protobuf:"varint,4,opt,name=trend,proto3,enum=mi8.Trend" json:"trend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CityScoreHistory) Reset() {
+	*x = CityScoreHistory{}
+	mi := &file_proto_mi8_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CityScoreHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityScoreHistory) ProtoMessage() {}
+
+func (x *CityScoreHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityScoreHistory.ProtoReflect.Descriptor instead.
+func (*CityScoreHistory) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CityScoreHistory) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CityScoreHistory) GetBucket() HistoryBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return HistoryBucket_DAY
+}
+
+func (x *CityScoreHistory) GetPoints() []*CityScorePoint {
+	if x != nil {
+		return x.Points
 	}
 	return nil
 }
 
+func (x *CityScoreHistory) GetTrend() Trend {
+	if x != nil {
+		return x.Trend
+	}
+	return Trend_TREND_UNKNOWN
+}
+
 var File_proto_mi8_proto protoreflect.FileDescriptor
 
 const file_proto_mi8_proto_rawDesc = "" +
 	"\n" +
-	"\x0fproto/mi8.proto\x12\x03mi8\x1a\x1bgoogle/protobuf/empty.proto\"\x88\x01\n" +
+	"\x0fproto/mi8.proto\x12\x03mi8\x1a\x1bgoogle/protobuf/empty.proto\"\x98\x01\n" +
 	"\x04News\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x12\x12\n" +
 	"\x04date\x18\x03 \x01(\tR\x04date\x12\x12\n" +
 	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x12\n" +
 	"\x04city\x18\x05 \x01(\tR\x04city\x12\x18\n" +
-	"\acountry\x18\x06 \x01(\tR\acountry\",\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\x12\x0e\n" +
+	"\x02id\x18\a \x01(\tR\x02id\" \n" +
+	"\x0eGetNewsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"#\n" +
+	"\x11DeleteNewsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
 	"\x14GetLatestNewsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\"F\n" +
 	"\x1aGetLatestNewsInCityRequest\x12\x12\n" +
@@ -490,9 +2096,41 @@ const file_proto_mi8_proto_rawDesc = "" +
 	"\bNewsList\x12\x1d\n" +
 	"\x04news\x18\x01 \x03(\v2\t.mi8.NewsR\x04news\")\n" +
 	"\x13GetCityScoreRequest\x12\x12\n" +
-	"\x04city\x18\x01 \x01(\tR\x04city\"+\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\"\xa5\x01\n" +
 	"\x13GetTopCitiesRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\"\xd0\x01\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x121\n" +
+	"\tdimension\x18\x02 \x01(\x0e2\x13.mi8.ScoreDimensionR\tdimension\x12+\n" +
+	"\aweights\x18\x03 \x01(\v2\x11.mi8.ScoreWeightsR\aweights\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\"2\n" +
+	"\x16GetCountryScoreRequest\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\"a\n" +
+	"\x16GetTopCountriesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x121\n" +
+	"\tdimension\x18\x02 \x01(\x0e2\x13.mi8.ScoreDimensionR\tdimension\"L\n" +
+	"\x1aListCitiesInCountryRequest\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x9b\x01\n" +
+	"\x0fDimensionScores\x12\x16\n" +
+	"\x06safety\x18\x01 \x01(\x01R\x06safety\x12\x18\n" +
+	"\aeconomy\x18\x02 \x01(\x01R\aeconomy\x12&\n" +
+	"\x0fquality_of_life\x18\x03 \x01(\x01R\rqualityOfLife\x12\x18\n" +
+	"\aculture\x18\x04 \x01(\x01R\aculture\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x01R\x05total\"\xf5\x01\n" +
+	"\fCountryScore\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x1d\n" +
+	"\n" +
+	"city_count\x18\x02 \x01(\x05R\tcityCount\x12(\n" +
+	"\x04mean\x18\x03 \x01(\v2\x14.mi8.DimensionScoresR\x04mean\x12,\n" +
+	"\x06median\x18\x04 \x01(\v2\x14.mi8.DimensionScoresR\x06median\x12)\n" +
+	"\bmin_city\x18\x05 \x01(\v2\x0e.mi8.CityScoreR\aminCity\x12)\n" +
+	"\bmax_city\x18\x06 \x01(\v2\x0e.mi8.CityScoreR\amaxCity\"=\n" +
+	"\x10CountryScoreList\x12)\n" +
+	"\x06scores\x18\x01 \x03(\v2\x11.mi8.CountryScoreR\x06scores\"\x82\x01\n" +
+	"\fScoreWeights\x12\x16\n" +
+	"\x06safety\x18\x01 \x01(\x01R\x06safety\x12\x18\n" +
+	"\aeconomy\x18\x02 \x01(\x01R\aeconomy\x12&\n" +
+	"\x0fquality_of_life\x18\x03 \x01(\x01R\rqualityOfLife\x12\x18\n" +
+	"\aculture\x18\x04 \x01(\x01R\aculture\"\xd0\x01\n" +
 	"\tCityScore\x12\x12\n" +
 	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
 	"\acountry\x18\x02 \x01(\tR\acountry\x12&\n" +
@@ -502,15 +2140,116 @@ const file_proto_mi8_proto_rawDesc = "" +
 	"\aculture\x18\x06 \x01(\x05R\aculture\x12!\n" +
 	"\flast_updated\x18\a \x01(\tR\vlastUpdated\"7\n" +
 	"\rCityScoreList\x12&\n" +
-	"\x06scores\x18\x01 \x03(\v2\x0e.mi8.CityScoreR\x06scores2\xc1\x02\n" +
+	"\x06scores\x18\x01 \x03(\v2\x0e.mi8.CityScoreR\x06scores\"y\n" +
+	"\x14SubscribeNewsRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vresume_from\x18\x04 \x01(\tR\n" +
+	"resumeFrom\":\n" +
+	"\tNewsEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\x04news\x18\x02 \x01(\v2\t.mi8.NewsR\x04news\"\x99\x01\n" +
+	"\x11IngestNewsSummary\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x05R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x02 \x01(\x05R\brejected\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x05R\n" +
+	"duplicates\x12,\n" +
+	"\x06errors\x18\x04 \x03(\v2\x14.mi8.IngestNewsErrorR\x06errors\"[\n" +
+	"\x0fIngestNewsError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1c\n" +
+	"\tduplicate\x18\x03 \x01(\bR\tduplicate\"\x80\x01\n" +
+	"\n" +
+	"ScoreDelta\x12\x16\n" +
+	"\x06safety\x18\x01 \x01(\x05R\x06safety\x12\x18\n" +
+	"\aeconomy\x18\x02 \x01(\x05R\aeconomy\x12&\n" +
+	"\x0fquality_of_life\x18\x03 \x01(\x05R\rqualityOfLife\x12\x18\n" +
+	"\aculture\x18\x04 \x01(\x05R\aculture\"c\n" +
+	"\n" +
+	"ImpactRule\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1a\n" +
+	"\bsynonyms\x18\x02 \x03(\tR\bsynonyms\x12'\n" +
+	"\x06impact\x18\x03 \x01(\v2\x0f.mi8.ScoreDeltaR\x06impact\"o\n" +
+	"\rImpactRuleSet\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x02 \x01(\tR\tupdatedAt\x12%\n" +
+	"\x05rules\x18\x03 \x03(\v2\x0f.mi8.ImpactRuleR\x05rules\"\x18\n" +
+	"\x16ListImpactRulesRequest\"f\n" +
+	"\x14PutImpactRuleRequest\x12#\n" +
+	"\x04rule\x18\x01 \x01(\v2\x0f.mi8.ImpactRuleR\x04rule\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"V\n" +
+	"\x17DeleteImpactRuleRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"\xff\x01\n" +
+	"\rImpactPreview\x12#\n" +
+	"\rrules_version\x18\x01 \x01(\x03R\frulesVersion\x12!\n" +
+	"\fmatched_tags\x18\x02 \x03(\tR\vmatchedTags\x12%\n" +
+	"\x0eunmatched_tags\x18\x03 \x03(\tR\runmatchedTags\x12'\n" +
+	"\x06impact\x18\x04 \x01(\v2\x0f.mi8.ScoreDeltaR\x06impact\x12(\n" +
+	"\acurrent\x18\x05 \x01(\v2\x0e.mi8.CityScoreR\acurrent\x12,\n" +
+	"\tprojected\x18\x06 \x01(\v2\x0e.mi8.CityScoreR\tprojected\"\x80\x01\n" +
+	"\x1aGetCityScoreHistoryRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12*\n" +
+	"\x06bucket\x18\x04 \x01(\x0e2\x12.mi8.HistoryBucketR\x06bucket\"\xca\x01\n" +
+	"\x0eCityScorePoint\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12&\n" +
+	"\x0fquality_of_life\x18\x02 \x01(\x05R\rqualityOfLife\x12\x16\n" +
+	"\x06safety\x18\x03 \x01(\x05R\x06safety\x12\x18\n" +
+	"\aeconomy\x18\x04 \x01(\x05R\aeconomy\x12\x18\n" +
+	"\aculture\x18\x05 \x01(\x05R\aculture\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x05R\x05total\x12\x18\n" +
+	"\asamples\x18\a \x01(\x05R\asamples\"\xa1\x01\n" +
+	"\x10CityScoreHistory\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12*\n" +
+	"\x06bucket\x18\x02 \x01(\x0e2\x12.mi8.HistoryBucketR\x06bucket\x12+\n" +
+	"\x06points\x18\x03 \x03(\v2\x13.mi8.CityScorePointR\x06points\x12 \n" +
+	"\x05trend\x18\x04 \x01(\x0e2\n" +
+	".mi8.TrendR\x05trend*V\n" +
+	"\x0eScoreDimension\x12\t\n" +
+	"\x05TOTAL\x10\x00\x12\n" +
+	"\n" +
+	"\x06SAFETY\x10\x01\x12\v\n" +
+	"\aECONOMY\x10\x02\x12\x13\n" +
+	"\x0fQUALITY_OF_LIFE\x10\x03\x12\v\n" +
+	"\aCULTURE\x10\x04*-\n" +
+	"\rHistoryBucket\x12\a\n" +
+	"\x03DAY\x10\x00\x12\b\n" +
+	"\x04WEEK\x10\x01\x12\t\n" +
+	"\x05MONTH\x10\x02*V\n" +
+	"\x05Trend\x12\x11\n" +
+	"\rTREND_UNKNOWN\x10\x00\x12\x13\n" +
+	"\x0fTREND_IMPROVING\x10\x01\x12\x10\n" +
+	"\fTREND_STABLE\x10\x02\x12\x13\n" +
+	"\x0fTREND_DECLINING\x10\x032\xef\b\n" +
 	"\n" +
 	"MI8Service\x12;\n" +
 	"\rGetLatestNews\x12\x19.mi8.GetLatestNewsRequest\x1a\r.mi8.NewsList\"\x00\x12G\n" +
-	"\x13GetLatestNewsInCity\x12\x1f.mi8.GetLatestNewsInCityRequest\x1a\r.mi8.NewsList\"\x00\x121\n" +
+	"\x13GetLatestNewsInCity\x12\x1f.mi8.GetLatestNewsInCityRequest\x1a\r.mi8.NewsList\"\x00\x12$\n" +
+	"\n" +
+	"CreateNews\x12\t.mi8.News\x1a\t.mi8.News\"\x00\x12+\n" +
+	"\aGetNews\x12\x13.mi8.GetNewsRequest\x1a\t.mi8.News\"\x00\x12$\n" +
 	"\n" +
-	"CreateNews\x12\t.mi8.News\x1a\x16.google.protobuf.Empty\"\x00\x12:\n" +
+	"UpdateNews\x12\t.mi8.News\x1a\t.mi8.News\"\x00\x12>\n" +
+	"\n" +
+	"DeleteNews\x12\x16.mi8.DeleteNewsRequest\x1a\x16.google.protobuf.Empty\"\x00\x12:\n" +
 	"\fGetCityScore\x12\x18.mi8.GetCityScoreRequest\x1a\x0e.mi8.CityScore\"\x00\x12>\n" +
-	"\fGetTopCities\x12\x18.mi8.GetTopCitiesRequest\x1a\x12.mi8.CityScoreList\"\x00B\vZ\tmi8/protob\x06proto3"
+	"\fGetTopCities\x12\x18.mi8.GetTopCitiesRequest\x1a\x12.mi8.CityScoreList\"\x00\x12O\n" +
+	"\x13GetCityScoreHistory\x12\x1f.mi8.GetCityScoreHistoryRequest\x1a\x15.mi8.CityScoreHistory\"\x00\x12C\n" +
+	"\x0fGetCountryScore\x12\x1b.mi8.GetCountryScoreRequest\x1a\x11.mi8.CountryScore\"\x00\x12G\n" +
+	"\x0fGetTopCountries\x12\x1b.mi8.GetTopCountriesRequest\x1a\x15.mi8.CountryScoreList\"\x00\x12L\n" +
+	"\x13ListCitiesInCountry\x12\x1f.mi8.ListCitiesInCountryRequest\x1a\x12.mi8.CityScoreList\"\x00\x12>\n" +
+	"\rSubscribeNews\x12\x19.mi8.SubscribeNewsRequest\x1a\x0e.mi8.NewsEvent\"\x000\x01\x123\n" +
+	"\n" +
+	"IngestNews\x12\t.mi8.News\x1a\x16.mi8.IngestNewsSummary\"\x00(\x01\x12D\n" +
+	"\x0fListImpactRules\x12\x1b.mi8.ListImpactRulesRequest\x1a\x12.mi8.ImpactRuleSet\"\x00\x12@\n" +
+	"\rPutImpactRule\x12\x19.mi8.PutImpactRuleRequest\x1a\x12.mi8.ImpactRuleSet\"\x00\x12F\n" +
+	"\x10DeleteImpactRule\x12\x1c.mi8.DeleteImpactRuleRequest\x1a\x12.mi8.ImpactRuleSet\"\x00\x124\n" +
+	"\x11PreviewNewsImpact\x12\t.mi8.News\x1a\x12.mi8.ImpactPreview\"\x00B\vZ\tmi8/protob\x06proto3"
 
 var (
 	file_proto_mi8_proto_rawDescOnce sync.Once
@@ -524,36 +2263,109 @@ func file_proto_mi8_proto_rawDescGZIP() []byte {
 	return file_proto_mi8_proto_rawDescData
 }
 
-var file_proto_mi8_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_mi8_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_mi8_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_proto_mi8_proto_goTypes = []any{
-	(*News)(nil),                       // 0: mi8.News
-	(*GetLatestNewsRequest)(nil),       // 1: mi8.GetLatestNewsRequest
-	(*GetLatestNewsInCityRequest)(nil), // 2: mi8.GetLatestNewsInCityRequest
-	(*NewsList)(nil),                   // 3: mi8.NewsList
-	(*GetCityScoreRequest)(nil),        // 4: mi8.GetCityScoreRequest
-	(*GetTopCitiesRequest)(nil),        // 5: mi8.GetTopCitiesRequest
-	(*CityScore)(nil),                  // 6: mi8.CityScore
-	(*CityScoreList)(nil),              // 7: mi8.CityScoreList
-	(*emptypb.Empty)(nil),              // 8: google.protobuf.Empty
+	(ScoreDimension)(0),                // 0: mi8.ScoreDimension
+	(HistoryBucket)(0),                 // 1: mi8.HistoryBucket
+	(Trend)(0),                         // 2: mi8.Trend
+	(*News)(nil),                       // 3: mi8.News
+	(*GetNewsRequest)(nil),             // 4: mi8.GetNewsRequest
+	(*DeleteNewsRequest)(nil),          // 5: mi8.DeleteNewsRequest
+	(*GetLatestNewsRequest)(nil),       // 6: mi8.GetLatestNewsRequest
+	(*GetLatestNewsInCityRequest)(nil), // 7: mi8.GetLatestNewsInCityRequest
+	(*NewsList)(nil),                   // 8: mi8.NewsList
+	(*GetCityScoreRequest)(nil),        // 9: mi8.GetCityScoreRequest
+	(*GetTopCitiesRequest)(nil),        // 10: mi8.GetTopCitiesRequest
+	(*GetCountryScoreRequest)(nil),     // 11: mi8.GetCountryScoreRequest
+	(*GetTopCountriesRequest)(nil),     // 12: mi8.GetTopCountriesRequest
+	(*ListCitiesInCountryRequest)(nil), // 13: mi8.ListCitiesInCountryRequest
+	(*DimensionScores)(nil),            // 14: mi8.DimensionScores
+	(*CountryScore)(nil),               // 15: mi8.CountryScore
+	(*CountryScoreList)(nil),           // 16: mi8.CountryScoreList
+	(*ScoreWeights)(nil),               // 17: mi8.ScoreWeights
+	(*CityScore)(nil),                  // 18: mi8.CityScore
+	(*CityScoreList)(nil),              // 19: mi8.CityScoreList
+	(*SubscribeNewsRequest)(nil),       // 20: mi8.SubscribeNewsRequest
+	(*NewsEvent)(nil),                  // 21: mi8.NewsEvent
+	(*IngestNewsSummary)(nil),          // 22: mi8.IngestNewsSummary
+	(*IngestNewsError)(nil),            // 23: mi8.IngestNewsError
+	(*ScoreDelta)(nil),                 // 24: mi8.ScoreDelta
+	(*ImpactRule)(nil),                 // 25: mi8.ImpactRule
+	(*ImpactRuleSet)(nil),              // 26: mi8.ImpactRuleSet
+	(*ListImpactRulesRequest)(nil),     // 27: mi8.ListImpactRulesRequest
+	(*PutImpactRuleRequest)(nil),       // 28: mi8.PutImpactRuleRequest
+	(*DeleteImpactRuleRequest)(nil),    // 29: mi8.DeleteImpactRuleRequest
+	(*ImpactPreview)(nil),              // 30: mi8.ImpactPreview
+	(*GetCityScoreHistoryRequest)(nil), // 31: mi8.GetCityScoreHistoryRequest
+	(*CityScorePoint)(nil),             // 32: mi8.CityScorePoint
+	(*CityScoreHistory)(nil),           // 33: mi8.CityScoreHistory
+	(*emptypb.Empty)(nil),              // 34: google.protobuf.Empty
 }
 var file_proto_mi8_proto_depIdxs = []int32{
-	0, // 0: mi8.NewsList.news:type_name -> mi8.News
-	6, // 1: mi8.CityScoreList.scores:type_name -> mi8.CityScore
-	1, // 2: mi8.MI8Service.GetLatestNews:input_type -> mi8.GetLatestNewsRequest
-	2, // 3: mi8.MI8Service.GetLatestNewsInCity:input_type -> mi8.GetLatestNewsInCityRequest
-	0, // 4: mi8.MI8Service.CreateNews:input_type -> mi8.News
-	4, // 5: mi8.MI8Service.GetCityScore:input_type -> mi8.GetCityScoreRequest
-	5, // 6: mi8.MI8Service.GetTopCities:input_type -> mi8.GetTopCitiesRequest
-	3, // 7: mi8.MI8Service.GetLatestNews:output_type -> mi8.NewsList
-	3, // 8: mi8.MI8Service.GetLatestNewsInCity:output_type -> mi8.NewsList
-	8, // 9: mi8.MI8Service.CreateNews:output_type -> google.protobuf.Empty
-	6, // 10: mi8.MI8Service.GetCityScore:output_type -> mi8.CityScore
-	7, // 11: mi8.MI8Service.GetTopCities:output_type -> mi8.CityScoreList
-	7, // [7:12] is the sub-list for method output_type
-	2, // [2:7] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3,  // 0: mi8.NewsList.news:type_name -> mi8.News
+	0,  // 1: mi8.GetTopCitiesRequest.dimension:type_name -> mi8.ScoreDimension
+	17, // 2: mi8.GetTopCitiesRequest.weights:type_name -> mi8.ScoreWeights
+	0,  // 3: mi8.GetTopCountriesRequest.dimension:type_name -> mi8.ScoreDimension
+	14, // 4: mi8.CountryScore.mean:type_name -> mi8.DimensionScores
+	14, // 5: mi8.CountryScore.median:type_name -> mi8.DimensionScores
+	18, // 6: mi8.CountryScore.min_city:type_name -> mi8.CityScore
+	18, // 7: mi8.CountryScore.max_city:type_name -> mi8.CityScore
+	15, // 8: mi8.CountryScoreList.scores:type_name -> mi8.CountryScore
+	18, // 9: mi8.CityScoreList.scores:type_name -> mi8.CityScore
+	3,  // 10: mi8.NewsEvent.news:type_name -> mi8.News
+	23, // 11: mi8.IngestNewsSummary.errors:type_name -> mi8.IngestNewsError
+	24, // 12: mi8.ImpactRule.impact:type_name -> mi8.ScoreDelta
+	25, // 13: mi8.ImpactRuleSet.rules:type_name -> mi8.ImpactRule
+	25, // 14: mi8.PutImpactRuleRequest.rule:type_name -> mi8.ImpactRule
+	24, // 15: mi8.ImpactPreview.impact:type_name -> mi8.ScoreDelta
+	18, // 16: mi8.ImpactPreview.current:type_name -> mi8.CityScore
+	18, // 17: mi8.ImpactPreview.projected:type_name -> mi8.CityScore
+	1,  // 18: mi8.GetCityScoreHistoryRequest.bucket:type_name -> mi8.HistoryBucket
+	1,  // 19: mi8.CityScoreHistory.bucket:type_name -> mi8.HistoryBucket
+	32, // 20: mi8.CityScoreHistory.points:type_name -> mi8.CityScorePoint
+	2,  // 21: mi8.CityScoreHistory.trend:type_name -> mi8.Trend
+	6,  // 22: mi8.MI8Service.GetLatestNews:input_type -> mi8.GetLatestNewsRequest
+	7,  // 23: mi8.MI8Service.GetLatestNewsInCity:input_type -> mi8.GetLatestNewsInCityRequest
+	3,  // 24: mi8.MI8Service.CreateNews:input_type -> mi8.News
+	4,  // 25: mi8.MI8Service.GetNews:input_type -> mi8.GetNewsRequest
+	3,  // 26: mi8.MI8Service.UpdateNews:input_type -> mi8.News
+	5,  // 27: mi8.MI8Service.DeleteNews:input_type -> mi8.DeleteNewsRequest
+	9,  // 28: mi8.MI8Service.GetCityScore:input_type -> mi8.GetCityScoreRequest
+	10, // 29: mi8.MI8Service.GetTopCities:input_type -> mi8.GetTopCitiesRequest
+	31, // 30: mi8.MI8Service.GetCityScoreHistory:input_type -> mi8.GetCityScoreHistoryRequest
+	11, // 31: mi8.MI8Service.GetCountryScore:input_type -> mi8.GetCountryScoreRequest
+	12, // 32: mi8.MI8Service.GetTopCountries:input_type -> mi8.GetTopCountriesRequest
+	13, // 33: mi8.MI8Service.ListCitiesInCountry:input_type -> mi8.ListCitiesInCountryRequest
+	20, // 34: mi8.MI8Service.SubscribeNews:input_type -> mi8.SubscribeNewsRequest
+	3,  // 35: mi8.MI8Service.IngestNews:input_type -> mi8.News
+	27, // 36: mi8.MI8Service.ListImpactRules:input_type -> mi8.ListImpactRulesRequest
+	28, // 37: mi8.MI8Service.PutImpactRule:input_type -> mi8.PutImpactRuleRequest
+	29, // 38: mi8.MI8Service.DeleteImpactRule:input_type -> mi8.DeleteImpactRuleRequest
+	3,  // 39: mi8.MI8Service.PreviewNewsImpact:input_type -> mi8.News
+	8,  // 40: mi8.MI8Service.GetLatestNews:output_type -> mi8.NewsList
+	8,  // 41: mi8.MI8Service.GetLatestNewsInCity:output_type -> mi8.NewsList
+	3,  // 42: mi8.MI8Service.CreateNews:output_type -> mi8.News
+	3,  // 43: mi8.MI8Service.GetNews:output_type -> mi8.News
+	3,  // 44: mi8.MI8Service.UpdateNews:output_type -> mi8.News
+	34, // 45: mi8.MI8Service.DeleteNews:output_type -> google.protobuf.Empty
+	18, // 46: mi8.MI8Service.GetCityScore:output_type -> mi8.CityScore
+	19, // 47: mi8.MI8Service.GetTopCities:output_type -> mi8.CityScoreList
+	33, // 48: mi8.MI8Service.GetCityScoreHistory:output_type -> mi8.CityScoreHistory
+	15, // 49: mi8.MI8Service.GetCountryScore:output_type -> mi8.CountryScore
+	16, // 50: mi8.MI8Service.GetTopCountries:output_type -> mi8.CountryScoreList
+	19, // 51: mi8.MI8Service.ListCitiesInCountry:output_type -> mi8.CityScoreList
+	21, // 52: mi8.MI8Service.SubscribeNews:output_type -> mi8.NewsEvent
+	22, // 53: mi8.MI8Service.IngestNews:output_type -> mi8.IngestNewsSummary
+	26, // 54: mi8.MI8Service.ListImpactRules:output_type -> mi8.ImpactRuleSet
+	26, // 55: mi8.MI8Service.PutImpactRule:output_type -> mi8.ImpactRuleSet
+	26, // 56: mi8.MI8Service.DeleteImpactRule:output_type -> mi8.ImpactRuleSet
+	30, // 57: mi8.MI8Service.PreviewNewsImpact:output_type -> mi8.ImpactPreview
+	40, // [40:58] is the sub-list for method output_type
+	22, // [22:40] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_proto_mi8_proto_init() }
@@ -566,13 +2378,14 @@ func file_proto_mi8_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_mi8_proto_rawDesc), len(file_proto_mi8_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_mi8_proto_goTypes,
 		DependencyIndexes: file_proto_mi8_proto_depIdxs,
+		EnumInfos:         file_proto_mi8_proto_enumTypes,
 		MessageInfos:      file_proto_mi8_proto_msgTypes,
 	}.Build()
 	File_proto_mi8_proto = out.File
@@ -23,8 +23,21 @@ const (
 	MI8Service_GetLatestNews_FullMethodName       = "/mi8.MI8Service/GetLatestNews"
 	MI8Service_GetLatestNewsInCity_FullMethodName = "/mi8.MI8Service/GetLatestNewsInCity"
 	MI8Service_CreateNews_FullMethodName          = "/mi8.MI8Service/CreateNews"
+	MI8Service_GetNews_FullMethodName             = "/mi8.MI8Service/GetNews"
+	MI8Service_UpdateNews_FullMethodName          = "/mi8.MI8Service/UpdateNews"
+	MI8Service_DeleteNews_FullMethodName          = "/mi8.MI8Service/DeleteNews"
 	MI8Service_GetCityScore_FullMethodName        = "/mi8.MI8Service/GetCityScore"
 	MI8Service_GetTopCities_FullMethodName        = "/mi8.MI8Service/GetTopCities"
+	MI8Service_GetCityScoreHistory_FullMethodName = "/mi8.MI8Service/GetCityScoreHistory"
+	MI8Service_GetCountryScore_FullMethodName     = "/mi8.MI8Service/GetCountryScore"
+	MI8Service_GetTopCountries_FullMethodName     = "/mi8.MI8Service/GetTopCountries"
+	MI8Service_ListCitiesInCountry_FullMethodName = "/mi8.MI8Service/ListCitiesInCountry"
+	MI8Service_SubscribeNews_FullMethodName       = "/mi8.MI8Service/SubscribeNews"
+	MI8Service_IngestNews_FullMethodName          = "/mi8.MI8Service/IngestNews"
+	MI8Service_ListImpactRules_FullMethodName     = "/mi8.MI8Service/ListImpactRules"
+	MI8Service_PutImpactRule_FullMethodName       = "/mi8.MI8Service/PutImpactRule"
+	MI8Service_DeleteImpactRule_FullMethodName    = "/mi8.MI8Service/DeleteImpactRule"
+	MI8Service_PreviewNewsImpact_FullMethodName   = "/mi8.MI8Service/PreviewNewsImpact"
 )
 
 // MI8ServiceClient is the client API for MI8Service service.
@@ -37,12 +50,38 @@ type MI8ServiceClient interface {
 	GetLatestNews(ctx context.Context, in *GetLatestNewsRequest, opts ...grpc.CallOption) (*NewsList, error)
 	// Get latest news by city
 	GetLatestNewsInCity(ctx context.Context, in *GetLatestNewsInCityRequest, opts ...grpc.CallOption) (*NewsList, error)
-	// Create news
-	CreateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Create news, the created news has its id set
+	CreateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*News, error)
+	// Get news by id
+	GetNews(ctx context.Context, in *GetNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Update news, its impact on city scores is updated too
+	UpdateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*News, error)
+	// Delete news, its impact on city scores is reversed
+	DeleteNews(ctx context.Context, in *DeleteNewsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Get city scores
 	GetCityScore(ctx context.Context, in *GetCityScoreRequest, opts ...grpc.CallOption) (*CityScore, error)
 	// Get top cities
 	GetTopCities(ctx context.Context, in *GetTopCitiesRequest, opts ...grpc.CallOption) (*CityScoreList, error)
+	// Get the evolution of a city's scores
+	GetCityScoreHistory(ctx context.Context, in *GetCityScoreHistoryRequest, opts ...grpc.CallOption) (*CityScoreHistory, error)
+	// Get the scores of the cities of a country, aggregated
+	GetCountryScore(ctx context.Context, in *GetCountryScoreRequest, opts ...grpc.CallOption) (*CountryScore, error)
+	// Get top countries, by the mean scores of their cities
+	GetTopCountries(ctx context.Context, in *GetTopCountriesRequest, opts ...grpc.CallOption) (*CountryScoreList, error)
+	// List the cities of a country, by total
+	ListCitiesInCountry(ctx context.Context, in *ListCitiesInCountryRequest, opts ...grpc.CallOption) (*CityScoreList, error)
+	// Stream news as they are created, optionally filtered
+	SubscribeNews(ctx context.Context, in *SubscribeNewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewsEvent], error)
+	// Create news in bulk, applied in batches
+	IngestNews(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[News, IngestNewsSummary], error)
+	// Get the rules giving the impact of news tags on city scores
+	ListImpactRules(ctx context.Context, in *ListImpactRulesRequest, opts ...grpc.CallOption) (*ImpactRuleSet, error)
+	// Add or replace the rule of a tag
+	PutImpactRule(ctx context.Context, in *PutImpactRuleRequest, opts ...grpc.CallOption) (*ImpactRuleSet, error)
+	// Remove the rule of a tag
+	DeleteImpactRule(ctx context.Context, in *DeleteImpactRuleRequest, opts ...grpc.CallOption) (*ImpactRuleSet, error)
+	// Show the effect a news would have on its city, without creating it
+	PreviewNewsImpact(ctx context.Context, in *News, opts ...grpc.CallOption) (*ImpactPreview, error)
 }
 
 type mI8ServiceClient struct {
@@ -73,9 +112,9 @@ func (c *mI8ServiceClient) GetLatestNewsInCity(ctx context.Context, in *GetLates
 	return out, nil
 }
 
-func (c *mI8ServiceClient) CreateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *mI8ServiceClient) CreateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*News, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(News)
 	err := c.cc.Invoke(ctx, MI8Service_CreateNews_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -83,6 +122,36 @@ func (c *mI8ServiceClient) CreateNews(ctx context.Context, in *News, opts ...grp
 	return out, nil
 }
 
+func (c *mI8ServiceClient) GetNews(ctx context.Context, in *GetNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, MI8Service_GetNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) UpdateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, MI8Service_UpdateNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) DeleteNews(ctx context.Context, in *DeleteNewsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MI8Service_DeleteNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mI8ServiceClient) GetCityScore(ctx context.Context, in *GetCityScoreRequest, opts ...grpc.CallOption) (*CityScore, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CityScore)
@@ -103,6 +172,118 @@ func (c *mI8ServiceClient) GetTopCities(ctx context.Context, in *GetTopCitiesReq
 	return out, nil
 }
 
+func (c *mI8ServiceClient) GetCityScoreHistory(ctx context.Context, in *GetCityScoreHistoryRequest, opts ...grpc.CallOption) (*CityScoreHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CityScoreHistory)
+	err := c.cc.Invoke(ctx, MI8Service_GetCityScoreHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) GetCountryScore(ctx context.Context, in *GetCountryScoreRequest, opts ...grpc.CallOption) (*CountryScore, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountryScore)
+	err := c.cc.Invoke(ctx, MI8Service_GetCountryScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) GetTopCountries(ctx context.Context, in *GetTopCountriesRequest, opts ...grpc.CallOption) (*CountryScoreList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountryScoreList)
+	err := c.cc.Invoke(ctx, MI8Service_GetTopCountries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) ListCitiesInCountry(ctx context.Context, in *ListCitiesInCountryRequest, opts ...grpc.CallOption) (*CityScoreList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CityScoreList)
+	err := c.cc.Invoke(ctx, MI8Service_ListCitiesInCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) SubscribeNews(ctx context.Context, in *SubscribeNewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewsEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MI8Service_ServiceDesc.Streams[0], MI8Service_SubscribeNews_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeNewsRequest, NewsEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MI8Service_SubscribeNewsClient = grpc.ServerStreamingClient[NewsEvent]
+
+func (c *mI8ServiceClient) IngestNews(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[News, IngestNewsSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MI8Service_ServiceDesc.Streams[1], MI8Service_IngestNews_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[News, IngestNewsSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MI8Service_IngestNewsClient = grpc.ClientStreamingClient[News, IngestNewsSummary]
+
+func (c *mI8ServiceClient) ListImpactRules(ctx context.Context, in *ListImpactRulesRequest, opts ...grpc.CallOption) (*ImpactRuleSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImpactRuleSet)
+	err := c.cc.Invoke(ctx, MI8Service_ListImpactRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) PutImpactRule(ctx context.Context, in *PutImpactRuleRequest, opts ...grpc.CallOption) (*ImpactRuleSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImpactRuleSet)
+	err := c.cc.Invoke(ctx, MI8Service_PutImpactRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) DeleteImpactRule(ctx context.Context, in *DeleteImpactRuleRequest, opts ...grpc.CallOption) (*ImpactRuleSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImpactRuleSet)
+	err := c.cc.Invoke(ctx, MI8Service_DeleteImpactRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) PreviewNewsImpact(ctx context.Context, in *News, opts ...grpc.CallOption) (*ImpactPreview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImpactPreview)
+	err := c.cc.Invoke(ctx, MI8Service_PreviewNewsImpact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MI8ServiceServer is the server API for MI8Service service.
 // All implementations must embed UnimplementedMI8ServiceServer
 // for forward compatibility.
@@ -113,12 +294,38 @@ type MI8ServiceServer interface {
 	GetLatestNews(context.Context, *GetLatestNewsRequest) (*NewsList, error)
 	// Get latest news by city
 	GetLatestNewsInCity(context.Context, *GetLatestNewsInCityRequest) (*NewsList, error)
-	// Create news
-	CreateNews(context.Context, *News) (*emptypb.Empty, error)
+	// Create news, the created news has its id set
+	CreateNews(context.Context, *News) (*News, error)
+	// Get news by id
+	GetNews(context.Context, *GetNewsRequest) (*News, error)
+	// Update news, its impact on city scores is updated too
+	UpdateNews(context.Context, *News) (*News, error)
+	// Delete news, its impact on city scores is reversed
+	DeleteNews(context.Context, *DeleteNewsRequest) (*emptypb.Empty, error)
 	// Get city scores
 	GetCityScore(context.Context, *GetCityScoreRequest) (*CityScore, error)
 	// Get top cities
 	GetTopCities(context.Context, *GetTopCitiesRequest) (*CityScoreList, error)
+	// Get the evolution of a city's scores
+	GetCityScoreHistory(context.Context, *GetCityScoreHistoryRequest) (*CityScoreHistory, error)
+	// Get the scores of the cities of a country, aggregated
+	GetCountryScore(context.Context, *GetCountryScoreRequest) (*CountryScore, error)
+	// Get top countries, by the mean scores of their cities
+	GetTopCountries(context.Context, *GetTopCountriesRequest) (*CountryScoreList, error)
+	// List the cities of a country, by total
+	ListCitiesInCountry(context.Context, *ListCitiesInCountryRequest) (*CityScoreList, error)
+	// Stream news as they are created, optionally filtered
+	SubscribeNews(*SubscribeNewsRequest, grpc.ServerStreamingServer[NewsEvent]) error
+	// Create news in bulk, applied in batches
+	IngestNews(grpc.ClientStreamingServer[News, IngestNewsSummary]) error
+	// Get the rules giving the impact of news tags on city scores
+	ListImpactRules(context.Context, *ListImpactRulesRequest) (*ImpactRuleSet, error)
+	// Add or replace the rule of a tag
+	PutImpactRule(context.Context, *PutImpactRuleRequest) (*ImpactRuleSet, error)
+	// Remove the rule of a tag
+	DeleteImpactRule(context.Context, *DeleteImpactRuleRequest) (*ImpactRuleSet, error)
+	// Show the effect a news would have on its city, without creating it
+	PreviewNewsImpact(context.Context, *News) (*ImpactPreview, error)
 	mustEmbedUnimplementedMI8ServiceServer()
 }
 
@@ -135,15 +342,54 @@ func (UnimplementedMI8ServiceServer) GetLatestNews(context.Context, *GetLatestNe
 func (UnimplementedMI8ServiceServer) GetLatestNewsInCity(context.Context, *GetLatestNewsInCityRequest) (*NewsList, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetLatestNewsInCity not implemented")
 }
-func (UnimplementedMI8ServiceServer) CreateNews(context.Context, *News) (*emptypb.Empty, error) {
+func (UnimplementedMI8ServiceServer) CreateNews(context.Context, *News) (*News, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateNews not implemented")
 }
+func (UnimplementedMI8ServiceServer) GetNews(context.Context, *GetNewsRequest) (*News, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNews not implemented")
+}
+func (UnimplementedMI8ServiceServer) UpdateNews(context.Context, *News) (*News, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateNews not implemented")
+}
+func (UnimplementedMI8ServiceServer) DeleteNews(context.Context, *DeleteNewsRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteNews not implemented")
+}
 func (UnimplementedMI8ServiceServer) GetCityScore(context.Context, *GetCityScoreRequest) (*CityScore, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetCityScore not implemented")
 }
 func (UnimplementedMI8ServiceServer) GetTopCities(context.Context, *GetTopCitiesRequest) (*CityScoreList, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTopCities not implemented")
 }
+func (UnimplementedMI8ServiceServer) GetCityScoreHistory(context.Context, *GetCityScoreHistoryRequest) (*CityScoreHistory, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCityScoreHistory not implemented")
+}
+func (UnimplementedMI8ServiceServer) GetCountryScore(context.Context, *GetCountryScoreRequest) (*CountryScore, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCountryScore not implemented")
+}
+func (UnimplementedMI8ServiceServer) GetTopCountries(context.Context, *GetTopCountriesRequest) (*CountryScoreList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTopCountries not implemented")
+}
+func (UnimplementedMI8ServiceServer) ListCitiesInCountry(context.Context, *ListCitiesInCountryRequest) (*CityScoreList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCitiesInCountry not implemented")
+}
+func (UnimplementedMI8ServiceServer) SubscribeNews(*SubscribeNewsRequest, grpc.ServerStreamingServer[NewsEvent]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeNews not implemented")
+}
+func (UnimplementedMI8ServiceServer) IngestNews(grpc.ClientStreamingServer[News, IngestNewsSummary]) error {
+	return status.Error(codes.Unimplemented, "method IngestNews not implemented")
+}
+func (UnimplementedMI8ServiceServer) ListImpactRules(context.Context, *ListImpactRulesRequest) (*ImpactRuleSet, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListImpactRules not implemented")
+}
+func (UnimplementedMI8ServiceServer) PutImpactRule(context.Context, *PutImpactRuleRequest) (*ImpactRuleSet, error) {
+	return nil, status.Error(codes.Unimplemented, "method PutImpactRule not implemented")
+}
+func (UnimplementedMI8ServiceServer) DeleteImpactRule(context.Context, *DeleteImpactRuleRequest) (*ImpactRuleSet, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteImpactRule not implemented")
+}
+func (UnimplementedMI8ServiceServer) PreviewNewsImpact(context.Context, *News) (*ImpactPreview, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewNewsImpact not implemented")
+}
 func (UnimplementedMI8ServiceServer) mustEmbedUnimplementedMI8ServiceServer() {}
 func (UnimplementedMI8ServiceServer) testEmbeddedByValue()                    {}
 
@@ -219,6 +465,60 @@ func _MI8Service_CreateNews_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MI8Service_GetNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).GetNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_GetNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).GetNews(ctx, req.(*GetNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_UpdateNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(News)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).UpdateNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_UpdateNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).UpdateNews(ctx, req.(*News))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_DeleteNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).DeleteNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_DeleteNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).DeleteNews(ctx, req.(*DeleteNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MI8Service_GetCityScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCityScoreRequest)
 	if err := dec(in); err != nil {
@@ -255,6 +555,168 @@ func _MI8Service_GetTopCities_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MI8Service_GetCityScoreHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCityScoreHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).GetCityScoreHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_GetCityScoreHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).GetCityScoreHistory(ctx, req.(*GetCityScoreHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_GetCountryScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountryScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).GetCountryScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_GetCountryScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).GetCountryScore(ctx, req.(*GetCountryScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_GetTopCountries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopCountriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).GetTopCountries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_GetTopCountries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).GetTopCountries(ctx, req.(*GetTopCountriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_ListCitiesInCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCitiesInCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).ListCitiesInCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_ListCitiesInCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).ListCitiesInCountry(ctx, req.(*ListCitiesInCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_SubscribeNews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeNewsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MI8ServiceServer).SubscribeNews(m, &grpc.GenericServerStream[SubscribeNewsRequest, NewsEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MI8Service_SubscribeNewsServer = grpc.ServerStreamingServer[NewsEvent]
+
+func _MI8Service_IngestNews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MI8ServiceServer).IngestNews(&grpc.GenericServerStream[News, IngestNewsSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MI8Service_IngestNewsServer = grpc.ClientStreamingServer[News, IngestNewsSummary]
+
+func _MI8Service_ListImpactRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImpactRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).ListImpactRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_ListImpactRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).ListImpactRules(ctx, req.(*ListImpactRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_PutImpactRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutImpactRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).PutImpactRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_PutImpactRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).PutImpactRule(ctx, req.(*PutImpactRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_DeleteImpactRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteImpactRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).DeleteImpactRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_DeleteImpactRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).DeleteImpactRule(ctx, req.(*DeleteImpactRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_PreviewNewsImpact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(News)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).PreviewNewsImpact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_PreviewNewsImpact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).PreviewNewsImpact(ctx, req.(*News))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MI8Service_ServiceDesc is the grpc.ServiceDesc for MI8Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -274,6 +736,18 @@ var MI8Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateNews",
 			Handler:    _MI8Service_CreateNews_Handler,
 		},
+		{
+			MethodName: "GetNews",
+			Handler:    _MI8Service_GetNews_Handler,
+		},
+		{
+			MethodName: "UpdateNews",
+			Handler:    _MI8Service_UpdateNews_Handler,
+		},
+		{
+			MethodName: "DeleteNews",
+			Handler:    _MI8Service_DeleteNews_Handler,
+		},
 		{
 			MethodName: "GetCityScore",
 			Handler:    _MI8Service_GetCityScore_Handler,
@@ -282,7 +756,50 @@ var MI8Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTopCities",
 			Handler:    _MI8Service_GetTopCities_Handler,
 		},
+		{
+			MethodName: "GetCityScoreHistory",
+			Handler:    _MI8Service_GetCityScoreHistory_Handler,
+		},
+		{
+			MethodName: "GetCountryScore",
+			Handler:    _MI8Service_GetCountryScore_Handler,
+		},
+		{
+			MethodName: "GetTopCountries",
+			Handler:    _MI8Service_GetTopCountries_Handler,
+		},
+		{
+			MethodName: "ListCitiesInCountry",
+			Handler:    _MI8Service_ListCitiesInCountry_Handler,
+		},
+		{
+			MethodName: "ListImpactRules",
+			Handler:    _MI8Service_ListImpactRules_Handler,
+		},
+		{
+			MethodName: "PutImpactRule",
+			Handler:    _MI8Service_PutImpactRule_Handler,
+		},
+		{
+			MethodName: "DeleteImpactRule",
+			Handler:    _MI8Service_DeleteImpactRule_Handler,
+		},
+		{
+			MethodName: "PreviewNewsImpact",
+			Handler:    _MI8Service_PreviewNewsImpact_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeNews",
+			Handler:       _MI8Service_SubscribeNews_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IngestNews",
+			Handler:       _MI8Service_IngestNews_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/mi8.proto",
 }
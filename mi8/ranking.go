@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"math"
+
+	pb "mi8/proto"
+)
+
+// City rankings.
+//
+// GetTopCities ranks cities by their total, by one dimension or by a weighted sum of the
+// dimensions, of their scores as read (rounded and clamped to 0), within a country or not. Ties are
+// ordered by name, descending, like ZREVRANGE.
+
+var (
+	errInvalidWeights   = errors.New("weights must be at least 0, one of them above")
+	errUnknownDimension = errors.New("unknown dimension")
+)
+
+// cityRanking orders cities by the weighted sum of their scores, within a country when set
+type cityRanking struct {
+	weights scoreVector
+	country string
+}
+
+// rankedField is a ranking kept as it is, by total or dimension, in the sorted sets of Redis
+type rankedField struct {
+	dimension pb.ScoreDimension
+	field     string
+	weights   scoreVector
+}
+
+var rankedFields = []rankedField{
+	{pb.ScoreDimension_TOTAL, "total", scoreVector{1, 1, 1, 1}},
+	{pb.ScoreDimension_SAFETY, "safety", scoreVector{safety: 1}},
+	{pb.ScoreDimension_ECONOMY, "economy", scoreVector{economy: 1}},
+	{pb.ScoreDimension_QUALITY_OF_LIFE, "qol", scoreVector{qol: 1}},
+	{pb.ScoreDimension_CULTURE, "culture", scoreVector{culture: 1}},
+}
+
+// totalRanking ranks all the cities by total
+var totalRanking = cityRanking{weights: rankedFields[0].weights}
+
+// rankingOf reads the ranking asked for by a GetTopCitiesRequest
+func rankingOf(in *pb.GetTopCitiesRequest) (cityRanking, error) {
+	ranking := cityRanking{country: in.Country}
+	if w := in.Weights; w != nil {
+		ranking.weights = scoreVector{w.Safety, w.Economy, w.QualityOfLife, w.Culture}
+		valid := func(w float64) bool { return w >= 0 && !math.IsInf(w, 1) }
+		v := ranking.weights
+		if !valid(v.safety) || !valid(v.economy) || !valid(v.qol) || !valid(v.culture) || v.safety+v.economy+v.qol+v.culture == 0 {
+			return cityRanking{}, errInvalidWeights
+		}
+		return ranking, nil
+	}
+	f, err := rankedFieldOf(in.Dimension)
+	ranking.weights = f.weights
+	return ranking, err
+}
+
+func rankedFieldOf(dimension pb.ScoreDimension) (rankedField, error) {
+	for _, f := range rankedFields {
+		if f.dimension == dimension {
+			return f, nil
+		}
+	}
+	return rankedField{}, errUnknownDimension
+}
+
+// field is the field of rankedFields the ranking is kept as, if any
+func (k cityRanking) field() (string, bool) {
+	for _, f := range rankedFields {
+		if f.weights == k.weights {
+			return f.field, true
+		}
+	}
+	return "", false
+}
+
+// value ranks a city, summing in the same order as ZUNIONSTORE so ties are the same
+func (k cityRanking) value(v scoreVector) float64 {
+	w := k.weights
+	value := float64(clampScore(v.safety)) * w.safety
+	value += float64(clampScore(v.economy)) * w.economy
+	value += float64(clampScore(v.qol)) * w.qol
+	value += float64(clampScore(v.culture)) * w.culture
+	return value
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	pb "mi8/proto"
+)
+
+// Country scores in Redis aggregate the scores of the cities of each country, listed by the sorted
+// set ranking them by total, decayed to now like GetCityScore, as the in-memory repository does.
+// Countries are ranked by countries:rank and countries:rank:<field>, which the scripts keep from
+// the sums of the sets ranking the cities of each country in country:score:<country>. These sets
+// hold the scores of the cities as of their last news or refresh of the rankings, so the order of
+// GetTopCountries can lag behind the means it returns while the scores decay.
+
+func (r *RedisNewsRepository) GetCountryScore(country string) (*pb.CountryScore, error) {
+	ctx := context.Background()
+	cities, err := r.client.ZRange(ctx, rankKey("total", country), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// The hashes are read in one transaction so the cities are scored at the same time. Cities
+	// never leave a country, one ranked since the list was read is left out as if read before.
+	hashes := make([]*redis.MapStringStringCmd, len(cities))
+	if _, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, city := range cities {
+			hashes[i] = pipe.HGetAll(ctx, "city:score:"+city)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	scores := make([]*pb.CityScore, 0, len(cities))
+	for i, city := range cities {
+		hash := hashes[i].Val()
+		if len(hash) == 0 {
+			continue
+		}
+		scores = append(scores, currentScores(hash, now).toProto(city, hash["country"], hash["last_updated"]))
+	}
+	if len(scores) == 0 {
+		return nil, errCountryNotFound
+	}
+	return aggregateCountry(country, scores), nil
+}
+
+func (r *RedisNewsRepository) GetTopCountries(by rankedField, limit int) ([]*pb.CountryScore, error) {
+	ctx := context.Background()
+	countries, err := r.client.ZRevRange(ctx, countryRankKey(by.field), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	scores := make([]*pb.CountryScore, 0, len(countries))
+	for _, country := range countries {
+		score, err := r.GetCountryScore(country)
+		if err != nil {
+			continue
+		}
+		scores = append(scores, score)
+	}
+	return scores, nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	pb "mi8/proto"
+)
+
+// Created news are appended to a Redis stream by createNewsScript, so every mi8 instance can serve subscriptions and
+// the stream entry ids are used to resume them. Only the latest entries are kept.
+//
+// Each process reads the stream in a single loop, started by the first subscription, and fans the entries out to its
+// subscriptions through a newsHub, so subscriptions don't hold Redis connections. Subscriptions resuming from an older
+// entry first read the entries they missed, without blocking, then follow the hub.
+const (
+	newsStreamKey    = "news:stream"
+	newsStreamMaxLen = 10000
+	// Entries read at once
+	newsStreamBatch = 100
+	// XREAD of the reading loop blocks at most this long
+	newsStreamBlock = 2 * time.Second
+)
+
+// SubscribeNews reads the stream after resumeFrom, or after the last entry read by the process when it is empty.
+func (r *RedisNewsRepository) SubscribeNews(resumeFrom string) (NewsSubscription, error) {
+	ctx := context.Background()
+	feed, err := r.newsFeed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resumeFrom != "" {
+		if _, _, ok := parseStreamID(resumeFrom); !ok {
+			return nil, errInvalidResumeID
+		}
+	}
+
+	live, liveFrom := feed.SubscribeLive()
+	sub := &redisNewsSubscription{client: r.client, lastID: liveFrom, liveFrom: liveFrom, live: live}
+	if resumeFrom == "" {
+		return sub, nil
+	}
+	sub.lastID = resumeFrom
+	if err := sub.checkNotTrimmed(ctx); err != nil {
+		live.Close()
+		return nil, err
+	}
+	return sub, nil
+}
+
+// newsFeed gives the hub of the stream entries, starting the reading loop after the last entry at the first call
+func (r *RedisNewsRepository) newsFeed(ctx context.Context) (*newsHub, error) {
+	r.feedMu.Lock()
+	defer r.feedMu.Unlock()
+	if r.feed != nil {
+		return r.feed, nil
+	}
+
+	lastID := "0-0"
+	last, err := r.client.XRevRangeN(ctx, newsStreamKey, "+", "-", 1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(last) > 0 {
+		lastID = last[0].ID
+	}
+	r.feed = newNewsHub()
+	r.feed.lastEventID = lastID
+	go r.readNewsStream(r.feed, lastID)
+	return r.feed, nil
+}
+
+// readNewsStream publishes the entries of the stream following lastID to the hub, as they are added
+func (r *RedisNewsRepository) readNewsStream(feed *newsHub, lastID string) {
+	ctx := context.Background()
+	for {
+		streams, err := r.client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{newsStreamKey, lastID},
+			Count:   newsStreamBatch,
+			Block:   newsStreamBlock,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Error reading the news stream: %v\n", err)
+			time.Sleep(newsStreamBlock)
+			continue
+		}
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				lastID = msg.ID
+				event, err := streamEvent(msg)
+				if err != nil {
+					fmt.Printf("Invalid news stream entry %s: %v\n", msg.ID, err)
+					continue
+				}
+				feed.PublishEvent(event)
+			}
+		}
+	}
+}
+
+// streamEvent reads the news of a stream entry
+func streamEvent(msg redis.XMessage) (*pb.NewsEvent, error) {
+	var news pb.News
+	data, _ := msg.Values["news"].(string)
+	if err := json.Unmarshal([]byte(data), &news); err != nil {
+		return nil, err
+	}
+	return &pb.NewsEvent{Id: msg.ID, News: &news}, nil
+}
+
+type redisNewsSubscription struct {
+	client *redis.Client
+	lastID string
+	// Entries up to liveFrom are read from the stream, the following ones come from live
+	liveFrom string
+	live     *hubSubscription
+	// Entries read but not delivered yet
+	pending []redis.XMessage
+}
+
+func (s *redisNewsSubscription) Next(ctx context.Context) (*pb.NewsEvent, error) {
+	for len(s.pending) == 0 && compareStreamIDs(s.lastID, s.liveFrom) < 0 {
+		msgs, err := s.client.XRangeN(ctx, newsStreamKey, "("+s.lastID, s.liveFrom, newsStreamBatch).Result()
+		if err != nil {
+			return nil, err
+		}
+		// Entries following the last delivered one may have been trimmed before being read
+		if err := s.checkNotTrimmed(ctx); err != nil {
+			return nil, err
+		}
+		if len(msgs) == 0 {
+			s.lastID = s.liveFrom
+		}
+		s.pending = msgs
+	}
+
+	if len(s.pending) > 0 {
+		msg := s.pending[0]
+		s.pending = s.pending[1:]
+		s.lastID = msg.ID
+		return streamEvent(msg)
+	}
+
+	for {
+		event, err := s.live.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// Resumed after entries the process hadn't read yet
+		if compareStreamIDs(event.Id, s.lastID) <= 0 {
+			continue
+		}
+		s.lastID = event.Id
+		return event, nil
+	}
+}
+
+func (s *redisNewsSubscription) Close() {
+	s.live.Close()
+}
+
+// checkNotTrimmed fails when entries following the last read one were removed from the stream.
+// Trimming only tells where the stream starts now, so a subscriber right at the trimmed boundary
+// is considered to have missed entries too.
+func (s *redisNewsSubscription) checkNotTrimmed(ctx context.Context) error {
+	info, err := s.client.XInfoStream(ctx, newsStreamKey).Result()
+	if err != nil {
+		if strings.Contains(err.Error(), "no such key") {
+			return nil
+		}
+		return err
+	}
+	if compareStreamIDs(s.lastID, info.LastGeneratedID) > 0 {
+		return errInvalidResumeID
+	}
+	if info.MaxDeletedEntryID != "" && compareStreamIDs(s.lastID, info.MaxDeletedEntryID) < 0 {
+		return errResumeExpired
+	}
+	if info.Length > 0 && info.EntriesAdded > info.Length && compareStreamIDs(s.lastID, info.FirstEntry.ID) < 0 {
+		return errResumeExpired
+	}
+	return nil
+}
+
+// parseStreamID splits a stream entry id "<ms>-<seq>", the sequence may be omitted
+func parseStreamID(id string) (ms, seq uint64, ok bool) {
+	msPart, seqPart, hasSeq := strings.Cut(id, "-")
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if hasSeq {
+		if seq, err = strconv.ParseUint(seqPart, 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return ms, seq, true
+}
+
+func compareStreamIDs(a, b string) int {
+	aMs, aSeq, _ := parseStreamID(a)
+	bMs, bSeq, _ := parseStreamID(b)
+	switch {
+	case aMs != bMs:
+		if aMs < bMs {
+			return -1
+		}
+		return 1
+	case aSeq != bSeq:
+		if aSeq < bSeq {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
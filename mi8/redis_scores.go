@@ -0,0 +1,581 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	pb "mi8/proto"
+)
+
+// City scores in Redis are the hashes city:score:<city>, with the four dimensions decayed to
+// decayed_at (unix seconds). Hashes written before decay have no decayed_at: their scores are
+// taken as they are now. Scores are kept unclamped so impacts can be reversed exactly, and are
+// clamped to 0 wherever they are read: GetCityScore, the rankings and the history.
+//
+// Cities are ranked by their scores as read in sorted sets: cities:rank by total and
+// cities:rank:<field> by dimension, and the same sets for each country, suffixed by
+// :country:<country>. Countries are ranked in countries:rank and countries:rank:<field> by the
+// mean scores of their cities. The scripts are given every key they use: the keys of a city's
+// country come from the country stored in its hash, read before, and the scripts changing a news
+// are given the news as read. When either changed meanwhile, the scripts return "conflict" without
+// changing anything, to be run again with keys read again. The keys of a news being in different
+// hash slots, the scripts expect a single Redis rather than a cluster.
+//
+// News are created, updated and deleted by Lua scripts, so that storing the news, indexing it and
+// updating the scores, history and ranking of its city happen at once or not at all, and
+// concurrent news for a city don't lose each other's impact.
+
+// scriptAttempts bounds the runs of a script returning "conflict"
+const scriptAttempts = 5
+
+var errConcurrentChange = errors.New("changed concurrently, try again")
+
+// Functions shared by the scripts
+const scoresLua = `
+local fields = {'safety', 'economy', 'qol', 'culture'}
+
+local function nums(from, count)
+  local t = {}
+  for i = 1, count do
+    t[i] = tonumber(ARGV[from + i - 1])
+  end
+  return t
+end
+
+-- What an impact applied at 'at' weighs at 'now', negated when sign is -1
+local function contribution(delta, at, now, halfLives, sign)
+  local add = {}
+  for i = 1, 4 do
+    local factor = 1
+    if halfLives[i] > 0 and now > at then
+      factor = math.pow(2, -(now - at) / halfLives[i])
+    end
+    add[i] = sign * delta[i] * factor
+  end
+  return add
+end
+
+-- Scores of a city decayed to now, unclamped
+local function decayed(cityKey, now, halfLives)
+  local decayedAt = tonumber(redis.call('HGET', cityKey, 'decayed_at') or now)
+  local scores = {}
+  for i = 1, 4 do
+    scores[i] = tonumber(redis.call('HGET', cityKey, fields[i]) or 1000)
+    if halfLives[i] > 0 and now > decayedAt then
+      scores[i] = 1000 + (scores[i] - 1000) * math.pow(2, -(now - decayedAt) / halfLives[i])
+    end
+  end
+  return scores
+end
+
+local function clamped(score)
+  return math.max(0, math.floor(score + 0.5))
+end
+
+-- The 16 keys ranking a city, from KEYS[from], by total then by dimension: cities:rank and
+-- cities:rank:<field>, the same sets for the cities of its country, suffixed by
+-- :country:<country>, country:score:<country>, and countries:rank and countries:rank:<field>
+local function rankingKeys(from)
+  return {unpack(KEYS, from, from + 15)}
+end
+
+-- Whether the country of a city, as stored or as given to a new city, is the one of its keys
+local function countryChanged(cityKey, country, keysCountry)
+  return (redis.call('HGET', cityKey, 'country') or country) ~= keysCountry
+end
+
+-- Ranks a city by its scores as read, in the sets of rankingKeys. Countries are ranked by the mean
+-- of their cities, from the sums of the sets of the country kept in country:score:<country>.
+local function rank(ranking, city, country, scores)
+  local names, values = {'total'}, {0}
+  for i = 1, 4 do
+    names[i + 1], values[i + 1] = fields[i], scores[i]
+    values[1] = values[1] + scores[i]
+  end
+
+  for i = 1, 5 do
+    redis.call('ZADD', ranking[i], values[i], city)
+  end
+  if not country or country == '' then
+    return
+  end
+
+  local countryKey = ranking[11]
+  if redis.call('EXISTS', countryKey) == 0 then
+    -- Sums of the cities ranked before they were kept
+    for i = 1, 5 do
+      local sum = 0
+      local ranked = redis.call('ZRANGE', ranking[5 + i], 0, -1, 'WITHSCORES')
+      for j = 2, #ranked, 2 do
+        sum = sum + tonumber(ranked[j])
+      end
+      redis.call('HSET', countryKey, names[i], sum)
+    end
+  end
+  for i = 1, 5 do
+    local cities = ranking[5 + i]
+    local old = tonumber(redis.call('ZSCORE', cities, city) or 0)
+    redis.call('ZADD', cities, values[i], city)
+    local sum = redis.call('HINCRBY', countryKey, names[i], values[i] - old)
+    redis.call('ZADD', ranking[11 + i], sum / redis.call('ZCARD', cities), country)
+  end
+end
+
+-- Decays the scores of a city to now and adds contributions. A new city starts at the base score
+-- with the given country. The new scores, as read, are recorded in the history, a sorted set of
+-- "<unix ms>:<safety>:<economy>:<qol>:<culture>" by unix ms, and give the city's rankings.
+local function updateCity(cityKey, historyKey, ranking, city, country, lastUpdated, add, now, halfLives, cutoff)
+  if redis.call('EXISTS', cityKey) == 0 then
+    redis.call('HSET', cityKey, 'safety', 1000, 'economy', 1000, 'qol', 1000, 'culture', 1000, 'country', country)
+  else
+    redis.call('HSETNX', cityKey, 'country', country)
+  end
+
+  local scores = decayed(cityKey, now, halfLives)
+  local read = {}
+  for i = 1, 4 do
+    scores[i] = scores[i] + add[i]
+    redis.call('HSET', cityKey, fields[i], string.format('%.6f', scores[i]))
+    read[i] = clamped(scores[i])
+  end
+  redis.call('HSET', cityKey, 'decayed_at', string.format('%.3f', now))
+  if lastUpdated ~= '' then
+    redis.call('HSET', cityKey, 'last_updated', lastUpdated)
+  end
+
+  local at = math.floor(now * 1000)
+  redis.call('ZADD', historyKey, at, at .. ':' .. table.concat(read, ':'))
+  redis.call('ZREMRANGEBYSCORE', historyKey, '-inf', '(' .. math.floor(cutoff * 1000))
+  rank(ranking, city, redis.call('HGET', cityKey, 'country'), read)
+end
+
+local function storeImpact(impactKey, delta, at)
+  redis.call('HSET', impactKey, 'safety', delta[1], 'economy', delta[2], 'qol', delta[3], 'culture', delta[4], 'at', at)
+end
+
+-- Removes what remains of the impact of a news, the fallback being used for news whose impact
+-- wasn't kept
+local function removeImpact(impactKey, cityKey, historyKey, ranking, city, country, now, halfLives, cutoff, delta, at)
+  local stored = redis.call('HMGET', impactKey, 'safety', 'economy', 'qol', 'culture', 'at')
+  if stored[1] then
+    delta = {tonumber(stored[1]), tonumber(stored[2]), tonumber(stored[3]), tonumber(stored[4])}
+  end
+  if stored[5] then
+    at = tonumber(stored[5])
+  end
+  redis.call('DEL', impactKey)
+  updateCity(cityKey, historyKey, ranking, city, country, '', contribution(delta, at, now, halfLives, -1), now, halfLives, cutoff)
+end
+`
+
+// KEYS: news, news:latest, news:city:<city>, news:impact:<id>, news:fingerprint:<fingerprint>,
+// news:stream, city:score:<city>, city:history:<city>, 16 ranking keys of the city
+// ARGV: id, news JSON, index score, city ("" for none), country, date, "1" to reject duplicates,
+// stream max length, now, 4 half-lives, history cutoff, 4 impact deltas, impact time, country of
+// the ranking keys
+var createNewsScript = redis.NewScript(scoresLua + `
+if ARGV[4] ~= '' and countryChanged(KEYS[7], ARGV[5], ARGV[20]) then
+  return 'conflict'
+end
+if redis.call('SETNX', KEYS[5], ARGV[1]) == 0 and ARGV[7] == '1' then
+  return 'duplicate'
+end
+redis.call('SET', KEYS[1], ARGV[2])
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[1])
+redis.call('XADD', KEYS[6], 'MAXLEN', '~', ARGV[8], '*', 'news', ARGV[2])
+if ARGV[4] ~= '' then
+  local now, halfLives, delta, at = tonumber(ARGV[9]), nums(10, 4), nums(15, 4), tonumber(ARGV[19])
+  redis.call('ZADD', KEYS[3], ARGV[3], ARGV[1])
+  storeImpact(KEYS[4], delta, at)
+  updateCity(KEYS[7], KEYS[8], rankingKeys(9), ARGV[4], ARGV[5], ARGV[6], contribution(delta, at, now, halfLives, 1), now, halfLives, tonumber(ARGV[14]))
+end
+return 'created'
+`)
+
+// KEYS: news, news:latest, old news:city:<city>, new news:city:<city>, news:impact:<id>,
+// old and new news:fingerprint:<fingerprint>, old city:score:<city> and city:history:<city>, new
+// city:score:<city> and city:history:<city>, 16 ranking keys of the old city, 16 of the new city
+// ARGV: id, news JSON, old city, old country, new city, new country, new date, now, 4 half-lives,
+// history cutoff, 4 fallback old impact deltas, fallback old impact time, 4 new impact deltas,
+// new impact time, old news JSON, countries of the ranking keys of the old and new cities
+var updateNewsScript = redis.NewScript(scoresLua + `
+local stored = redis.call('GET', KEYS[1])
+if not stored then
+  return 'not_found'
+end
+if stored ~= ARGV[24] or (ARGV[3] ~= '' and countryChanged(KEYS[8], ARGV[4], ARGV[25]))
+    or (ARGV[5] ~= '' and countryChanged(KEYS[10], ARGV[6], ARGV[26])) then
+  return 'conflict'
+end
+local now, halfLives, cutoff = tonumber(ARGV[8]), nums(9, 4), tonumber(ARGV[13])
+redis.call('SET', KEYS[1], ARGV[2])
+if ARGV[3] ~= ARGV[5] then
+  if ARGV[3] ~= '' then
+    redis.call('ZREM', KEYS[3], ARGV[1])
+  end
+  if ARGV[5] ~= '' then
+    redis.call('ZADD', KEYS[4], redis.call('ZSCORE', KEYS[2], ARGV[1]) or 0, ARGV[1])
+  end
+end
+if ARGV[3] ~= '' then
+  removeImpact(KEYS[5], KEYS[8], KEYS[9], rankingKeys(12), ARGV[3], ARGV[4], now, halfLives, cutoff, nums(14, 4), tonumber(ARGV[18]))
+end
+if ARGV[5] ~= '' then
+  local delta, at = nums(19, 4), tonumber(ARGV[23])
+  storeImpact(KEYS[5], delta, at)
+  updateCity(KEYS[10], KEYS[11], rankingKeys(28), ARGV[5], ARGV[6], ARGV[7], contribution(delta, at, now, halfLives, 1), now, halfLives, cutoff)
+end
+if redis.call('GET', KEYS[6]) == ARGV[1] then
+  redis.call('DEL', KEYS[6])
+end
+redis.call('SETNX', KEYS[7], ARGV[1])
+return 'updated'
+`)
+
+// KEYS: news, news:latest, news:city:<city>, news:impact:<id>, news:fingerprint:<fingerprint>,
+// city:score:<city>, city:history:<city>, 16 ranking keys of the city
+// ARGV: id, city, country, now, 4 half-lives, history cutoff, 4 fallback impact deltas, fallback
+// impact time, news JSON, country of the ranking keys
+var deleteNewsScript = redis.NewScript(scoresLua + `
+local stored = redis.call('GET', KEYS[1])
+if not stored then
+  return 'not_found'
+end
+if stored ~= ARGV[15] or (ARGV[2] ~= '' and countryChanged(KEYS[6], ARGV[3], ARGV[16])) then
+  return 'conflict'
+end
+redis.call('DEL', KEYS[1])
+redis.call('ZREM', KEYS[2], ARGV[1])
+if redis.call('GET', KEYS[5]) == ARGV[1] then
+  redis.call('DEL', KEYS[5])
+end
+if ARGV[2] ~= '' then
+  redis.call('ZREM', KEYS[3], ARGV[1])
+  removeImpact(KEYS[4], KEYS[6], KEYS[7], rankingKeys(8), ARGV[2], ARGV[3], tonumber(ARGV[4]), nums(5, 4), tonumber(ARGV[9]), nums(10, 4), tonumber(ARGV[14]))
+end
+return 'deleted'
+`)
+
+// KEYS: city:score:<city>, 16 ranking keys of the city
+// ARGV: city, now, 4 half-lives, history cutoff (unused), country of the ranking keys
+var rankCityScript = redis.NewScript(scoresLua + `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return 'not_found'
+end
+if countryChanged(KEYS[1], '', ARGV[8]) then
+  return 'conflict'
+end
+local scores = decayed(KEYS[1], tonumber(ARGV[2]), nums(3, 4))
+for i = 1, 4 do
+  scores[i] = clamped(scores[i])
+end
+rank(rankingKeys(2), ARGV[1], redis.call('HGET', KEYS[1], 'country'), scores)
+return 'ranked'
+`)
+
+// newsScripts are loaded when connecting, so pipelines can run them by their SHA
+var newsScripts = []*redis.Script{createNewsScript, updateNewsScript, deleteNewsScript, rankCityScript}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1000
+}
+
+// decayArgs are the arguments of the scripts about decay: now, the half-lives and the history cutoff
+func decayArgs(now time.Time) []interface{} {
+	h := scoreHalfLives
+	return []interface{}{unixSeconds(now), h.safety.Seconds(), h.economy.Seconds(), h.qol.Seconds(), h.culture.Seconds(), unixSeconds(now.Add(-scoreHistoryRetention))}
+}
+
+func impactArgs(impact appliedImpact) []interface{} {
+	d := impact.delta
+	return []interface{}{d.safety, d.economy, d.qol, d.culture, unixSeconds(impact.at)}
+}
+
+// rankingKeys are the keys ranking a city of a country, as expected by the scripts: the sets
+// ranking the cities, the sets ranking the cities of the country, the sums of the country and the
+// sets ranking the countries, each by the fields of rankedFields
+func rankingKeys(country string) []string {
+	keys := make([]string, 0, 16)
+	for _, f := range rankedFields {
+		keys = append(keys, rankKey(f.field, ""))
+	}
+	for _, f := range rankedFields {
+		keys = append(keys, rankKey(f.field, country))
+	}
+	keys = append(keys, "country:score:"+country)
+	for _, f := range rankedFields {
+		keys = append(keys, countryRankKey(f.field))
+	}
+	return keys
+}
+
+// createNewsArgs gives the keys and arguments of createNewsScript for a news with its id set, the
+// country of its city coming from cityCountries
+func createNewsArgs(news *pb.News, countries map[string]string, order float64, rejectDuplicate bool, now time.Time) ([]string, []interface{}, error) {
+	data, err := json.Marshal(news)
+	if err != nil {
+		return nil, nil, err
+	}
+	keys := []string{
+		"news:" + news.Id, "news:latest", "news:city:" + news.City, "news:impact:" + news.Id,
+		"news:fingerprint:" + newsFingerprint(news), newsStreamKey,
+		"city:score:" + news.City, "city:history:" + news.City,
+	}
+	keys = append(keys, rankingKeys(countries[news.City])...)
+	reject := "0"
+	if rejectDuplicate {
+		reject = "1"
+	}
+	args := []interface{}{news.Id, data, order, news.City, news.Country, news.Date, reject, newsStreamMaxLen}
+	args = append(args, decayArgs(now)...)
+	args = append(args, impactArgs(appliedImpact{delta: calculateImpact(news.Tags), at: newsTime(news, now)})...)
+	args = append(args, countries[news.City])
+	return keys, args, nil
+}
+
+// updateNewsArgs gives the keys and arguments of updateNewsScript for the news old, stored as
+// stored, becoming news
+func updateNewsArgs(old *pb.News, stored string, news *pb.News, countries map[string]string, now time.Time) ([]string, []interface{}, error) {
+	data, err := json.Marshal(news)
+	if err != nil {
+		return nil, nil, err
+	}
+	keys := []string{
+		"news:" + news.Id, "news:latest", "news:city:" + old.City, "news:city:" + news.City, "news:impact:" + news.Id,
+		"news:fingerprint:" + newsFingerprint(old), "news:fingerprint:" + newsFingerprint(news),
+		"city:score:" + old.City, "city:history:" + old.City, "city:score:" + news.City, "city:history:" + news.City,
+	}
+	keys = append(keys, rankingKeys(countries[old.City])...)
+	keys = append(keys, rankingKeys(countries[news.City])...)
+	args := []interface{}{news.Id, data, old.City, old.Country, news.City, news.Country, news.Date}
+	args = append(args, decayArgs(now)...)
+	args = append(args, impactArgs(appliedImpact{delta: calculateImpact(old.Tags), at: newsTime(old, now)})...)
+	args = append(args, impactArgs(appliedImpact{delta: calculateImpact(news.Tags), at: newsTime(news, now)})...)
+	args = append(args, stored, countries[old.City], countries[news.City])
+	return keys, args, nil
+}
+
+// deleteNewsArgs gives the keys and arguments of deleteNewsScript for a news stored as stored
+func deleteNewsArgs(news *pb.News, stored string, countries map[string]string, now time.Time) ([]string, []interface{}) {
+	keys := []string{
+		"news:" + news.Id, "news:latest", "news:city:" + news.City, "news:impact:" + news.Id,
+		"news:fingerprint:" + newsFingerprint(news),
+		"city:score:" + news.City, "city:history:" + news.City,
+	}
+	keys = append(keys, rankingKeys(countries[news.City])...)
+	args := []interface{}{news.Id, news.City, news.Country}
+	args = append(args, decayArgs(now)...)
+	args = append(args, impactArgs(appliedImpact{delta: calculateImpact(news.Tags), at: newsTime(news, now)})...)
+	args = append(args, stored, countries[news.City])
+	return keys, args
+}
+
+// cityCountries reads the countries of the cities of the news from their hashes, for the keys of
+// the scripts. A city not stored yet takes the country of its first news.
+func (r *RedisNewsRepository) cityCountries(ctx context.Context, news ...*pb.News) (map[string]string, error) {
+	stored := map[string]*redis.StringCmd{}
+	pipe := r.client.Pipeline()
+	for _, n := range news {
+		if n.City != "" && stored[n.City] == nil {
+			stored[n.City] = pipe.HGet(ctx, "city:score:"+n.City, "country")
+		}
+	}
+	if len(stored) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+			return nil, err
+		}
+	}
+
+	countries := map[string]string{}
+	for _, n := range news {
+		if _, ok := countries[n.City]; ok || n.City == "" {
+			continue
+		}
+		country, err := stored[n.City].Result()
+		if err == redis.Nil {
+			country = n.Country
+		} else if err != nil {
+			return nil, err
+		}
+		countries[n.City] = country
+	}
+	return countries, nil
+}
+
+// storedNews reads a news with the JSON it is stored as, which the scripts changing it check to be
+// unchanged
+func (r *RedisNewsRepository) storedNews(ctx context.Context, id string) (*pb.News, string, error) {
+	data, err := r.client.Get(ctx, "news:"+id).Result()
+	if err == redis.Nil {
+		return nil, "", errNewsNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	var news pb.News
+	if err := json.Unmarshal([]byte(data), &news); err != nil {
+		return nil, "", fmt.Errorf("invalid news %s: %v", id, err)
+	}
+	// News stored before they had an id
+	if news.Id == "" {
+		news.Id = id
+	}
+	return &news, data, nil
+}
+
+// runNewsScript runs a script with the keys and arguments of build, built again as long as the
+// script returns "conflict", up to scriptAttempts times
+func (r *RedisNewsRepository) runNewsScript(ctx context.Context, script *redis.Script, build func() ([]string, []interface{}, error)) (string, error) {
+	for attempt := 1; ; attempt++ {
+		keys, args, err := build()
+		if err != nil {
+			return "", err
+		}
+		res, err := script.Run(ctx, r.client, keys, args...).Text()
+		if err != nil || res != "conflict" {
+			return res, err
+		}
+		if attempt == scriptAttempts {
+			return "", errConcurrentChange
+		}
+	}
+}
+
+// currentScores decays the scores of a city hash to now
+func currentScores(hash map[string]string, now time.Time) scoreVector {
+	toFloat := func(s string) float64 {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return baseScore
+		}
+		return v
+	}
+	v := scoreVector{toFloat(hash["safety"]), toFloat(hash["economy"]), toFloat(hash["qol"]), toFloat(hash["culture"])}
+	decayedAt, err := strconv.ParseFloat(hash["decayed_at"], 64)
+	if err != nil {
+		return v
+	}
+	return scoreHalfLives.decay(v, time.UnixMilli(int64(decayedAt*1000)), now)
+}
+
+// countryRankKey is the sorted set ranking countries by the mean of a field of rankedFields
+func countryRankKey(field string) string {
+	if field == "total" {
+		return "countries:rank"
+	}
+	return "countries:rank:" + field
+}
+
+// rankKey is the sorted set ranking cities by a field of rankedFields, within a country when set
+func rankKey(field, country string) string {
+	key := "cities:rank"
+	if field != "total" {
+		key += ":" + field
+	}
+	if country != "" {
+		key += ":country:" + country
+	}
+	return key
+}
+
+// refreshRankings ranks the cities again as their scores decay, the rankings only being updated
+// when news arrive otherwise. The first refresh, when starting, fills the rankings missing for
+// cities ranked before there was one per dimension and country.
+func (r *RedisNewsRepository) refreshRankings(interval time.Duration) {
+	r.rankCities()
+	for range time.Tick(interval) {
+		r.rankCities()
+	}
+}
+
+// rankCities ranks every city again. Cities whose country changed meanwhile are left to the next
+// refresh.
+func (r *RedisNewsRepository) rankCities() {
+	ctx := context.Background()
+	cities, err := r.client.ZRange(ctx, "cities:rank", 0, -1).Result()
+	if err != nil {
+		fmt.Printf("Error refreshing rankings: %v\n", err)
+		return
+	}
+	news := make([]*pb.News, len(cities))
+	for i, city := range cities {
+		news[i] = &pb.News{City: city}
+	}
+	countries, err := r.cityCountries(ctx, news...)
+	if err != nil {
+		fmt.Printf("Error refreshing rankings: %v\n", err)
+		return
+	}
+
+	pipe := r.client.Pipeline()
+	args := decayArgs(time.Now())
+	for _, city := range cities {
+		keys := append([]string{"city:score:" + city}, rankingKeys(countries[city])...)
+		rankCityScript.EvalSha(ctx, pipe, keys, append(append([]interface{}{city}, args...), countries[city])...)
+	}
+	_, err = pipe.Exec(ctx)
+	if redis.HasErrorPrefix(err, "NOSCRIPT") {
+		// Loaded again for the next refresh
+		err = loadNewsScripts(ctx, r.client)
+	}
+	if err != nil {
+		fmt.Printf("Error refreshing rankings: %v\n", err)
+	}
+}
+
+// rankedCities reads a ranking from its sorted set, or from the union of the sets of the
+// dimensions with their weights for weighted rankings. The union goes to a temporary set, removed
+// in the same transaction.
+func (r *RedisNewsRepository) rankedCities(ctx context.Context, ranking cityRanking, limit int) ([]string, error) {
+	if field, ok := ranking.field(); ok {
+		return r.client.ZRevRange(ctx, rankKey(field, ranking.country), 0, int64(limit-1)).Result()
+	}
+
+	tmp := "cities:rank:weighted:" + uuid.New().String()
+	w := ranking.weights
+	var ranked *redis.StringSliceCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZUnionStore(ctx, tmp, &redis.ZStore{
+			Keys: []string{
+				rankKey("safety", ranking.country), rankKey("economy", ranking.country),
+				rankKey("qol", ranking.country), rankKey("culture", ranking.country),
+			},
+			Weights: []float64{w.safety, w.economy, w.qol, w.culture},
+		})
+		ranked = pipe.ZRevRange(ctx, tmp, 0, int64(limit-1))
+		pipe.Del(ctx, tmp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ranked.Val(), nil
+}
+
+// scoreHistory reads the records of a city between from and to
+func (r *RedisNewsRepository) scoreHistory(ctx context.Context, city string, from, to time.Time) ([]scorePoint, error) {
+	members, err := r.client.ZRangeByScore(ctx, "city:history:"+city, &redis.ZRangeBy{
+		Min: strconv.FormatInt(from.UnixMilli(), 10),
+		Max: strconv.FormatInt(to.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	points := make([]scorePoint, 0, len(members))
+	for _, member := range members {
+		var ms int64
+		var p scorePoint
+		if _, err := fmt.Sscanf(member, "%d:%d:%d:%d:%d", &ms, &p.safety, &p.economy, &p.qol, &p.culture); err != nil {
+			continue
+		}
+		p.at = time.UnixMilli(ms)
+		points = append(points, p)
+	}
+	return points, nil
+}
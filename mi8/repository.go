@@ -1,29 +1,85 @@
 package main
 
 import (
+	"github.com/google/uuid"
 	pb "mi8/proto"
+	"google.golang.org/protobuf/proto"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+    "errors"
     "fmt"
 )
 
+// Returned by GetCityScore for a city without any scored news
+var errCityNotFound = errors.New("city scores not found")
+
+// Returned for an unknown news id
+var errNewsNotFound = errors.New("news not found")
+
 // NewsRepository interface
 type NewsRepository interface {
 	GetLatestNews(limit int) ([]*pb.News, error)
 	GetLatestNewsInCity(city string, limit int) ([]*pb.News, error)
-	CreateNews(news *pb.News) error
+	// CreateNews gives the news a new id and returns it
+	CreateNews(news *pb.News) (*pb.News, error)
+	GetNews(id string) (*pb.News, error)
+	// UpdateNews replaces the news with the same id, moving its impact on city scores
+	UpdateNews(news *pb.News) (*pb.News, error)
+	// DeleteNews removes a news and reverses its impact on city scores
+	DeleteNews(id string) error
     GetCityScore(city string) (*pb.CityScore, error)
-    GetTopCities(limit int) ([]*pb.CityScore, error)
+    // GetTopCities gives the first cities of a ranking, all of them when limit is 0
+    GetTopCities(ranking cityRanking, limit int) ([]*pb.CityScore, error)
+    // GetCountryScore aggregates the scores of the cities of a country
+    GetCountryScore(country string) (*pb.CountryScore, error)
+    // GetTopCountries ranks countries by the mean of a field of their cities, all of them when limit is 0
+    GetTopCountries(by rankedField, limit int) ([]*pb.CountryScore, error)
+    // GetCityScoreHistory gives the records of a city's scores between from and to, by time
+    GetCityScoreHistory(city string, from, to time.Time) ([]scorePoint, error)
+    // SubscribeNews follows the created news, after resumeFrom when set
+    SubscribeNews(resumeFrom string) (NewsSubscription, error)
+    // IngestNews creates a batch of news, the error of each news is errDuplicateNews for duplicates
+    IngestNews(batch []*pb.News) ([]error, error)
 }
 
 // ArrayNewsRepository implementation
 type ArrayNewsRepository struct {
-	newsStore []*pb.News
+	mu           sync.RWMutex
+	newsStore    []*pb.News
+	fingerprints map[string]bool
+	cities       map[string]*cityScores
+	impacts      map[string]appliedImpact // by news id
+	history      map[string][]scorePoint  // by city
+	feed         *newsHub
+}
+
+// cityScores of the in-memory repository, kept like the city:score hashes in Redis: decayed to
+// decayedAt, clamped to 0 when read
+type cityScores struct {
+    country, lastUpdated string
+    scores               scoreVector
+    decayedAt            time.Time
+}
+
+func (c *cityScores) current(now time.Time) scoreVector {
+    return scoreHalfLives.decay(c.scores, c.decayedAt, now)
+}
+
+func (c *cityScores) add(v scoreVector, now time.Time) {
+    c.scores = c.current(now).add(v)
+    c.decayedAt = now
 }
 
 func NewArrayNewsRepository() *ArrayNewsRepository {
 	repo := &ArrayNewsRepository{
-		newsStore: []*pb.News{},
+		newsStore:    []*pb.News{},
+		fingerprints: map[string]bool{},
+		cities:       map[string]*cityScores{},
+		impacts:      map[string]appliedImpact{},
+		history:      map[string][]scorePoint{},
+		feed:         newNewsHub(),
 	}
     // Populate with test data
     repo.CreateNews(&pb.News{
@@ -61,16 +117,86 @@ func NewArrayNewsRepository() *ArrayNewsRepository {
 	return repo
 }
 
-func (r *ArrayNewsRepository) CreateNews(news *pb.News) error {
+func (r *ArrayNewsRepository) CreateNews(news *pb.News) (*pb.News, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.createNews(news)
+	return news, nil
+}
+
+func (r *ArrayNewsRepository) createNews(news *pb.News) {
+    news.Id = uuid.New().String()
     // Prepend for "latest" behavior efficiently, or just append and sort on query.
     // Given the requirement "GetLatestNews", usually newest first.
     // Simple prepend here.
     r.newsStore = append([]*pb.News{news}, r.newsStore...)
+    r.fingerprints[newsFingerprint(news)] = true
+    r.applyImpact(news)
+    r.feed.Publish(news)
     fmt.Printf("News created: %s in %s\n", news.Name, news.City)
-	return nil
+}
+
+func (r *ArrayNewsRepository) IngestNews(batch []*pb.News) ([]error, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    errs := make([]error, len(batch))
+    for i, news := range batch {
+        if r.fingerprints[newsFingerprint(news)] {
+            errs[i] = errDuplicateNews
+            continue
+        }
+        r.createNews(news)
+    }
+    return errs, nil
+}
+
+// GetNews returns a copy, like the Redis repository, so callers can't change the stored news
+func (r *ArrayNewsRepository) GetNews(id string) (*pb.News, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    i := r.indexOf(id)
+    if i < 0 { return nil, errNewsNotFound }
+    return proto.Clone(r.newsStore[i]).(*pb.News), nil
+}
+
+// UpdateNews and DeleteNews copy the store, slices returned by the getters are never modified
+func (r *ArrayNewsRepository) UpdateNews(news *pb.News) (*pb.News, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    i := r.indexOf(news.Id)
+    if i < 0 { return nil, errNewsNotFound }
+    delete(r.fingerprints, newsFingerprint(r.newsStore[i]))
+    r.fingerprints[newsFingerprint(news)] = true
+    r.reverseImpact(r.newsStore[i])
+    r.applyImpact(news)
+    store := append([]*pb.News{}, r.newsStore...)
+    store[i] = news
+    r.newsStore = store
+    return news, nil
+}
+
+func (r *ArrayNewsRepository) DeleteNews(id string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    i := r.indexOf(id)
+    if i < 0 { return errNewsNotFound }
+    delete(r.fingerprints, newsFingerprint(r.newsStore[i]))
+    r.reverseImpact(r.newsStore[i])
+    store := append([]*pb.News{}, r.newsStore[:i]...)
+    r.newsStore = append(store, r.newsStore[i+1:]...)
+    return nil
+}
+
+func (r *ArrayNewsRepository) indexOf(id string) int {
+    for i, n := range r.newsStore {
+        if n.Id == id { return i }
+    }
+    return -1
 }
 
 func (r *ArrayNewsRepository) GetLatestNews(limit int) ([]*pb.News, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
 	if limit > len(r.newsStore) {
 		limit = len(r.newsStore)
 	}
@@ -78,6 +204,8 @@ func (r *ArrayNewsRepository) GetLatestNews(limit int) ([]*pb.News, error) {
 }
 
 func (r *ArrayNewsRepository) GetLatestNewsInCity(city string, limit int) ([]*pb.News, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
 	var filtered []*pb.News
 	for _, n := range r.newsStore {
 		if strings.EqualFold(n.City, city) {
@@ -90,10 +218,130 @@ func (r *ArrayNewsRepository) GetLatestNewsInCity(city string, limit int) ([]*pb
 	return filtered[:limit], nil
 }
 
+// applyImpact applies the impact of a news on its city's scores, keeping it to reverse it later
+// with the rules it was applied with. A city starts at the base score with the country of its
+// first news.
+func (r *ArrayNewsRepository) applyImpact(news *pb.News) {
+    if news.City == "" { return }
+    now := time.Now()
+    c := r.cities[news.City]
+    if c == nil {
+        c = &cityScores{country: news.Country, scores: baseScores, decayedAt: now}
+        r.cities[news.City] = c
+    }
+    impact := appliedImpact{delta: calculateImpact(news.Tags), at: newsTime(news, now)}
+    r.impacts[news.Id] = impact
+    c.add(scoreHalfLives.contribution(impact.delta, impact.at, now), now)
+    r.recordScores(news.City, now)
+    if news.Date != "" { c.lastUpdated = news.Date }
+}
+
+// reverseImpact removes what remains of the impact of a news
+func (r *ArrayNewsRepository) reverseImpact(news *pb.News) {
+    impact, ok := r.impacts[news.Id]
+    if !ok { return }
+    delete(r.impacts, news.Id)
+    now := time.Now()
+    r.cities[news.City].add(scoreHalfLives.contribution(impact.delta.scaled(-1), impact.at, now), now)
+    r.recordScores(news.City, now)
+}
+
+// recordScores adds the scores of a city to its history, dropping the records past the retention
+func (r *ArrayNewsRepository) recordScores(city string, now time.Time) {
+    history := append(r.history[city], pointOf(r.cities[city].current(now), now))
+    cutoff := now.Add(-scoreHistoryRetention)
+    for len(history) > 0 && history[0].at.Before(cutoff) {
+        history = history[1:]
+    }
+    r.history[city] = history
+}
+
+func (r *ArrayNewsRepository) GetCityScoreHistory(city string, from, to time.Time) ([]scorePoint, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    if r.cities[city] == nil { return nil, errCityNotFound }
+    var points []scorePoint
+    for _, p := range r.history[city] {
+        if !p.at.Before(from) && !p.at.After(to) { points = append(points, p) }
+    }
+    return points, nil
+}
+
 func (r *ArrayNewsRepository) GetCityScore(city string) (*pb.CityScore, error) {
-    return &pb.CityScore{City: city, Safety: 1000}, nil
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    c := r.cities[city]
+    if c == nil { return nil, errCityNotFound }
+    return c.current(time.Now()).toProto(city, c.country, c.lastUpdated), nil
+}
+
+// GetTopCities orders cities like ZREVRANGE on their ranking set: by current value, then by name,
+// descending
+func (r *ArrayNewsRepository) GetTopCities(ranking cityRanking, limit int) ([]*pb.CityScore, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    now := time.Now()
+    current := make(map[string]scoreVector, len(r.cities))
+    value := make(map[string]float64, len(r.cities))
+    cities := make([]string, 0, len(r.cities))
+    for city, c := range r.cities {
+        if ranking.country != "" && c.country != ranking.country { continue }
+        current[city] = c.current(now)
+        value[city] = ranking.value(current[city])
+        cities = append(cities, city)
+    }
+    sort.Slice(cities, func(i, j int) bool {
+        vi, vj := value[cities[i]], value[cities[j]]
+        if vi != vj { return vi > vj }
+        return cities[i] > cities[j]
+    })
+    if limit > 0 && limit < len(cities) { cities = cities[:limit] }
+
+    scores := make([]*pb.CityScore, 0, len(cities))
+    for _, city := range cities {
+        c := r.cities[city]
+        scores = append(scores, current[city].toProto(city, c.country, c.lastUpdated))
+    }
+    return scores, nil
+}
+
+// countryCities gives the current scores of the cities of each country
+func (r *ArrayNewsRepository) countryCities() map[string][]*pb.CityScore {
+    now := time.Now()
+    byCountry := map[string][]*pb.CityScore{}
+    for city, c := range r.cities {
+        if c.country == "" { continue }
+        byCountry[c.country] = append(byCountry[c.country], c.current(now).toProto(city, c.country, c.lastUpdated))
+    }
+    return byCountry
+}
+
+func (r *ArrayNewsRepository) GetCountryScore(country string) (*pb.CountryScore, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    cities := r.countryCities()[country]
+    if len(cities) == 0 { return nil, errCountryNotFound }
+    return aggregateCountry(country, cities), nil
+}
+
+// GetTopCountries orders countries like ZREVRANGE on their ranking set: by mean, then by name,
+// descending
+func (r *ArrayNewsRepository) GetTopCountries(by rankedField, limit int) ([]*pb.CountryScore, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    var scores []*pb.CountryScore
+    for country, cities := range r.countryCities() {
+        scores = append(scores, aggregateCountry(country, cities))
+    }
+    sort.Slice(scores, func(i, j int) bool {
+        mi, mj := by.of(scores[i].Mean), by.of(scores[j].Mean)
+        if mi != mj { return mi > mj }
+        return scores[i].Country > scores[j].Country
+    })
+    if limit > 0 && limit < len(scores) { scores = scores[:limit] }
+    return scores, nil
 }
 
-func (r *ArrayNewsRepository) GetTopCities(limit int) ([]*pb.CityScore, error) {
-    return []*pb.CityScore{}, nil
+func (r *ArrayNewsRepository) SubscribeNews(resumeFrom string) (NewsSubscription, error) {
+    return r.feed.Subscribe(resumeFrom)
 }
@@ -28,6 +28,47 @@ echo "Verifying news in MI8..."
 
 echo "Verifying Top Cities..."
 ./grpcurl -plaintext -d '{"limit": 5}' localhost:50051 mi8.MI8Service/GetTopCities
+./grpcurl -plaintext -d '{"limit": 5, "dimension": "SAFETY"}' localhost:50051 mi8.MI8Service/GetTopCities
+./grpcurl -plaintext -d '{"limit": 5, "weights": {"economy": 2, "culture": 1}, "country": "Germany"}' localhost:50051 mi8.MI8Service/GetTopCities
+
+echo "Verifying country scores..."
+./grpcurl -plaintext -d '{"country": "Germany"}' localhost:50051 mi8.MI8Service/GetCountryScore
+./grpcurl -plaintext -d '{"limit": 5, "dimension": "CULTURE"}' localhost:50051 mi8.MI8Service/GetTopCountries
+./grpcurl -plaintext -d '{"country": "Germany"}' localhost:50051 mi8.MI8Service/ListCitiesInCountry
 
 echo "Verifying Berlin Score..."
 ./grpcurl -plaintext -d '{"city": "Berlin"}' localhost:50051 mi8.MI8Service/GetCityScore
+
+echo "Verifying SubscribeNews..."
+./grpcurl -plaintext -max-time 5 -d '{"city": "Lyon"}' localhost:50051 mi8.MI8Service/SubscribeNews > subscribe.out 2>&1 &
+SUBSCRIBER=$!
+sleep 1
+./grpcurl -plaintext -d '{"name": "Subscription check", "source": "verify", "tags": ["Culture"], "city": "Lyon", "country": "France"}' localhost:50051 mi8.MI8Service/CreateNews
+wait $SUBSCRIBER || true
+grep -q "Subscription check" subscribe.out && echo "Subscriber received the news"
+rm -f subscribe.out
+
+echo "Verifying IngestNews..."
+./grpcurl -plaintext -d @ localhost:50051 mi8.MI8Service/IngestNews <<JSON
+{"name": "Ingested concert", "source": "verify", "date": "2026-03-01T20:00:00Z", "tags": ["Entertainment"], "city": "Lyon", "country": "France"}
+{"name": "Ingested concert", "source": "verify", "date": "2026-03-01T20:00:00Z", "tags": ["Entertainment"], "city": "Lyon", "country": "France"}
+{"name": "", "city": "Lyon"}
+JSON
+
+echo "Verifying news CRUD..."
+NEWS_ID=$(./grpcurl -plaintext -d '{"name": "Museum opening", "source": "verify", "tags": ["Culture"], "city": "Lyon", "country": "France"}' localhost:50051 mi8.MI8Service/CreateNews | grep '"id"' | cut -d'"' -f4)
+./grpcurl -plaintext -d "{\"id\": \"$NEWS_ID\"}" localhost:50051 mi8.MI8Service/GetNews
+./grpcurl -plaintext -d "{\"id\": \"$NEWS_ID\", \"name\": \"Museum closing\", \"source\": \"verify\", \"tags\": [\"Crisis\"], \"city\": \"Lyon\", \"country\": \"France\"}" localhost:50051 mi8.MI8Service/UpdateNews
+./grpcurl -plaintext -d "{\"id\": \"$NEWS_ID\"}" localhost:50051 mi8.MI8Service/DeleteNews
+./grpcurl -plaintext -d '{"city": "Lyon"}' localhost:50051 mi8.MI8Service/GetCityScore
+
+echo "Running the repository conformance suite..."
+go test ./...
+
+echo "Verifying impact rules..."
+./grpcurl -plaintext localhost:50051 mi8.MI8Service/ListImpactRules
+./grpcurl -plaintext -d '{"rule": {"tag": "strike", "synonyms": ["walkout"], "impact": {"safety": -10, "economy": -40, "qualityOfLife": -20}}}' localhost:50051 mi8.MI8Service/PutImpactRule
+./grpcurl -plaintext -d '{"name": "Transport strike", "tags": ["Walkout", "Crime"], "city": "Lyon", "country": "France"}' localhost:50051 mi8.MI8Service/PreviewNewsImpact
+
+echo "Verifying Lyon score history..."
+./grpcurl -plaintext -d '{"city": "Lyon", "bucket": "DAY"}' localhost:50051 mi8.MI8Service/GetCityScoreHistory
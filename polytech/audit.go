@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Audit actions
+const (
+	auditStudentDeleted  = "student.deleted"
+	auditStudentExported = "student.exported"
+	auditStudentErased   = "student.erased"
+)
+
+type AuditEntry struct {
+	ID             int64           `json:"id"`
+	StudentID      *int            `json:"studentId,omitempty"`
+	RegistrationID *int            `json:"registrationId,omitempty"`
+	Action         string          `json:"action"`
+	Details        json.RawMessage `json:"details"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// recordAudit stores an audit entry about a student inside the caller's transaction
+func recordAudit(ctx context.Context, tx pgx.Tx, studentID int, action string, details interface{}) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx,
+		"INSERT INTO audit_log (student_id, action, details) VALUES ($1, $2, $3)",
+		studentID, action, data)
+	return err
+}
+
+// auditEntriesForStudent lists the entries about the student or one of their registrations
+func auditEntriesForStudent(ctx context.Context, q pgx.Tx, studentID int) ([]AuditEntry, error) {
+	rows, err := q.Query(ctx, `
+		SELECT id, student_id, registration_id, action, details, created_at FROM audit_log
+		WHERE student_id=$1 OR registration_id IN (SELECT id FROM registrations WHERE student_id=$1)
+		ORDER BY id`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (AuditEntry, error) {
+		var e AuditEntry
+		err := row.Scan(&e.ID, &e.StudentID, &e.RegistrationID, &e.Action, &e.Details, &e.CreatedAt)
+		return e, err
+	})
+}
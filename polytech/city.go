@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"polytech/client"
+)
+
+// City context from MI8, shown to students next to the offers.
+//
+// Handlers needing MI8 are methods of mi8Handlers, which is given the client in main.
+type mi8Handlers struct {
+	mi8 *client.MI8Client
+}
+
+// GET /city/{name}/news?limit=<n>
+func (h *mi8Handlers) getCityNews(w http.ResponseWriter, r *http.Request) {
+	limit, ok := limitParam(w, r, 10)
+	if !ok {
+		return
+	}
+
+	news, err := h.mi8.GetLatestNewsInCity(r.Context(), chi.URLParam(r, "name"), limit)
+	if err != nil {
+		mi8Error(w, err)
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, news)
+}
+
+// GET /city/{name}/score
+func (h *mi8Handlers) getCityScore(w http.ResponseWriter, r *http.Request) {
+	score, err := h.mi8.GetCityScore(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		mi8Error(w, err)
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, score)
+}
+
+// GET /city/top?limit=<n>
+func (h *mi8Handlers) getTopCities(w http.ResponseWriter, r *http.Request) {
+	limit, ok := limitParam(w, r, 10)
+	if !ok {
+		return
+	}
+
+	scores, err := h.mi8.GetTopCities(r.Context(), limit)
+	if err != nil {
+		mi8Error(w, err)
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, scores)
+}
+
+// limitParam reads ?limit= (1 to 100), answering 400 when it is invalid
+func limitParam(w http.ResponseWriter, r *http.Request, def int) (int, bool) {
+	v := r.URL.Query().Get("limit")
+	if v == "" {
+		return def, true
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit < 1 || limit > 100 {
+		http.Error(w, "Invalid limit, expected 1 to 100", http.StatusBadRequest)
+		return 0, false
+	}
+	return limit, true
+}
+
+func mi8Error(w http.ResponseWriter, err error) {
+	if errors.Is(err, client.ErrNotFound) {
+		http.Error(w, "City not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, "MI8 unavailable: "+err.Error(), http.StatusBadGateway)
+}
@@ -2,46 +2,237 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 	pb "polytech/proto"
 )
 
+// Returned when MI8 doesn't know the requested city
+var ErrNotFound = errors.New("not found in MI8")
+
+type News struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Source  string   `json:"source"`
+	Date    string   `json:"date"`
+	Tags    []string `json:"tags"`
+	City    string   `json:"city"`
+	Country string   `json:"country"`
+}
+
+// CityScore is what MI8 learned about a city from its news
+type CityScore struct {
+	City          string `json:"city"`
+	Country       string `json:"country"`
+	QualityOfLife int    `json:"qualityOfLife"`
+	Safety        int    `json:"safety"`
+	Economy       int    `json:"economy"`
+	Culture       int    `json:"culture"`
+	LastUpdated   string `json:"lastUpdated"`
+}
+
+// Config of a MI8Client. Zero values take the defaults.
+type Config struct {
+	Addr string
+	// Deadline of calls whose context has none (default 3s)
+	CallTimeout time.Duration
+	// Interval of the keepalive pings on an idle connection (default 30s). MI8 allows pings every
+	// 20s, shorter intervals get the connection closed.
+	KeepaliveTime time.Duration
+	// Maximum delay between reconnection attempts (default 30s)
+	MaxBackoff time.Duration
+	// Attempts of idempotent calls failing with UNAVAILABLE, including the first (default 3)
+	MaxAttempts int
+}
+
+// Retries of the read-only RPCs, done by gRPC itself. CreateNews is never retried as it isn't idempotent.
+const serviceConfig = `{
+	"methodConfig": [{
+		"name": [
+			{"service": "mi8.MI8Service", "method": "GetLatestNews"},
+			{"service": "mi8.MI8Service", "method": "GetLatestNewsInCity"},
+			{"service": "mi8.MI8Service", "method": "GetCityScore"},
+			{"service": "mi8.MI8Service", "method": "GetTopCities"}
+		],
+		"retryPolicy": {
+			"maxAttempts": %d,
+			"initialBackoff": "0.1s",
+			"maxBackoff": "1s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]
+}`
+
+// MI8Client is a long-lived client of the MI8 service, safe for concurrent use.
+// The connection is made in the background and re-established with backoff when lost.
 type MI8Client struct {
-	client pb.MI8ServiceClient
-    conn *grpc.ClientConn
+	client      pb.MI8ServiceClient
+	conn        *grpc.ClientConn
+	callTimeout time.Duration
 }
 
-func NewMI8Client(addr string) (*MI8Client, error) {
-	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewMI8Client(cfg Config) (*MI8Client, error) {
+	if cfg.CallTimeout <= 0 {
+		cfg.CallTimeout = 3 * time.Second
+	}
+	if cfg.KeepaliveTime <= 0 {
+		cfg.KeepaliveTime = 30 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 30 * time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+
+	backoffConfig := backoff.DefaultConfig
+	backoffConfig.MaxDelay = cfg.MaxBackoff
+	conn, err := grpc.NewClient(cfg.Addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoffConfig, MinConnectTimeout: 5 * time.Second}),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.KeepaliveTime,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithDefaultServiceConfig(fmt.Sprintf(serviceConfig, cfg.MaxAttempts)),
+	)
 	if err != nil {
-		return nil, fmt.Errorf("did not connect: %v", err)
+		return nil, fmt.Errorf("invalid MI8 client configuration: %v", err)
 	}
+	// Connect now rather than on the first call, so readiness reflects MI8 from the start
+	conn.Connect()
 	c := pb.NewMI8ServiceClient(conn)
-	return &MI8Client{client: c, conn: conn}, nil
+	return &MI8Client{client: c, conn: conn, callTimeout: cfg.CallTimeout}, nil
 }
 
 func (c *MI8Client) Close() {
-    c.conn.Close()
+	c.conn.Close()
+}
+
+// Ready tells whether the connection to MI8 is up. An idle connection is woken up, so a
+// later call reports it.
+func (c *MI8Client) Ready() bool {
+	state := c.conn.GetState()
+	if state == connectivity.Idle {
+		c.conn.Connect()
+	}
+	return state == connectivity.Ready
+}
+
+// withDeadline applies the default call timeout when ctx has no deadline
+func (c *MI8Client) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.callTimeout)
 }
 
-func (c *MI8Client) GetLatestNews(limit int32) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (c *MI8Client) GetLatestNews(ctx context.Context, limit int) ([]News, error) {
+	ctx, cancel := c.withDeadline(ctx)
 	defer cancel()
+	r, err := c.client.GetLatestNews(ctx, &pb.GetLatestNewsRequest{Limit: int32(limit)})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return newsFromProto(r.GetNews()), nil
+}
 
-	r, err := c.client.GetLatestNews(ctx, &pb.GetLatestNewsRequest{Limit: limit})
+func (c *MI8Client) GetLatestNewsInCity(ctx context.Context, city string, limit int) ([]News, error) {
+	ctx, cancel := c.withDeadline(ctx)
+	defer cancel()
+	r, err := c.client.GetLatestNewsInCity(ctx, &pb.GetLatestNewsInCityRequest{City: city, Limit: int32(limit)})
 	if err != nil {
-		log.Printf("could not get news: %v", err)
-        return
+		return nil, convertError(err)
 	}
-	
-    fmt.Printf("--- Latest News from MI8 (Limit: %d) ---\n", limit)
-	for _, n := range r.GetNews() {
-		fmt.Printf("Title: %s | City: %s | Date: %s\n", n.Name, n.City, n.Date)
+	return newsFromProto(r.GetNews()), nil
+}
+
+func (c *MI8Client) CreateNews(ctx context.Context, n News) (News, error) {
+	ctx, cancel := c.withDeadline(ctx)
+	defer cancel()
+	created, err := c.client.CreateNews(ctx, &pb.News{
+		Name:    n.Name,
+		Source:  n.Source,
+		Date:    n.Date,
+		Tags:    n.Tags,
+		City:    n.City,
+		Country: n.Country,
+	})
+	if err != nil {
+		return News{}, convertError(err)
+	}
+	return newsFromProto([]*pb.News{created})[0], nil
+}
+
+// GetCityScore returns the scores of a city, computed by MI8 from its news
+func (c *MI8Client) GetCityScore(ctx context.Context, city string) (*CityScore, error) {
+	ctx, cancel := c.withDeadline(ctx)
+	defer cancel()
+	r, err := c.client.GetCityScore(ctx, &pb.GetCityScoreRequest{City: city})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	score := cityScoreFromProto(r)
+	return &score, nil
+}
+
+// GetTopCities returns the best ranked cities, best first
+func (c *MI8Client) GetTopCities(ctx context.Context, limit int) ([]CityScore, error) {
+	ctx, cancel := c.withDeadline(ctx)
+	defer cancel()
+	r, err := c.client.GetTopCities(ctx, &pb.GetTopCitiesRequest{Limit: int32(limit)})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	scores := make([]CityScore, 0, len(r.GetScores()))
+	for _, s := range r.GetScores() {
+		scores = append(scores, cityScoreFromProto(s))
+	}
+	return scores, nil
+}
+
+func convertError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, status.Convert(err).Message())
+	}
+	return err
+}
+
+func newsFromProto(list []*pb.News) []News {
+	news := make([]News, 0, len(list))
+	for _, n := range list {
+		news = append(news, News{
+			ID:      n.GetId(),
+			Name:    n.GetName(),
+			Source:  n.GetSource(),
+			Date:    n.GetDate(),
+			Tags:    n.GetTags(),
+			City:    n.GetCity(),
+			Country: n.GetCountry(),
+		})
+	}
+	return news
+}
+
+func cityScoreFromProto(s *pb.CityScore) CityScore {
+	return CityScore{
+		City:          s.GetCity(),
+		Country:       s.GetCountry(),
+		QualityOfLife: int(s.GetQualityOfLife()),
+		Safety:        int(s.GetSafety()),
+		Economy:       int(s.GetEconomy()),
+		Culture:       int(s.GetCulture()),
+		LastUpdated:   s.GetLastUpdated(),
 	}
-    fmt.Println("----------------------------------------")
 }
@@ -0,0 +1,505 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// Internship documents and agreement workflow.
+//
+// Once approved, a registration needs documents (REQUIRED_DOCUMENTS) and the tripartite
+// agreement signed by the student, the company and the school. Documents are uploaded per
+// registration, their content goes to the DocumentStore. The registration can only be
+// confirmed once every required document is there and the current agreement is signed by all.
+
+// Document type of the tripartite agreement, always required
+const agreementDocument = "agreement"
+
+// Parties signing the agreement
+var signatureParties = []string{"student", "company", "school"}
+
+var documentTypePattern = regexp.MustCompile(`^[a-z0-9_-]{1,50}$`)
+
+type Document struct {
+	ID             int       `json:"id"`
+	RegistrationID int       `json:"registrationId"`
+	Type           string    `json:"type"`
+	Filename       string    `json:"filename"`
+	ContentType    string    `json:"contentType"`
+	Size           int64     `json:"size"`
+	SHA256         string    `json:"sha256"`
+	UploadedAt     time.Time `json:"uploadedAt"`
+}
+
+type Signature struct {
+	Party      string    `json:"party"`
+	SignedBy   string    `json:"signedBy"`
+	SignedAt   time.Time `json:"signedAt"`
+	DocumentID int       `json:"documentId"`
+}
+
+type requiredDocument struct {
+	Type     string    `json:"type"`
+	Document *Document `json:"document"` // latest upload, null when missing
+}
+
+// agreementStatus is the checklist blocking confirmation
+type agreementStatus struct {
+	RegistrationID int                   `json:"registrationId"`
+	Status         string                `json:"status"`
+	Documents      []requiredDocument    `json:"documents"`
+	Signatures     map[string]*Signature `json:"signatures"` // by party, on the current agreement
+	Missing        []string              `json:"missing"`
+	Complete       bool                  `json:"complete"`
+}
+
+// requiredDocuments reads REQUIRED_DOCUMENTS (comma-separated types, default "agreement,insurance")
+func requiredDocuments() []string {
+	v := os.Getenv("REQUIRED_DOCUMENTS")
+	if v == "" {
+		v = "agreement,insurance"
+	}
+	types := []string{agreementDocument}
+	for _, t := range strings.Split(v, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" && t != agreementDocument {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// lockRegistrationForDocuments locks the registration, checking documents can still change
+func lockRegistrationForDocuments(ctx context.Context, tx pgx.Tx, id int) (status string, studentID int, err error) {
+	err = tx.QueryRow(ctx, "SELECT status, student_id FROM registrations WHERE id=$1 FOR UPDATE", id).Scan(&status, &studentID)
+	return status, studentID, err
+}
+
+// Statuses in which documents are uploaded and signed
+func acceptsDocuments(status string) bool {
+	return status == statusApproved || status == statusNeedsAttention
+}
+
+func scanDocument(row pgx.Row) (Document, error) {
+	var d Document
+	err := row.Scan(&d.ID, &d.RegistrationID, &d.Type, &d.Filename, &d.ContentType, &d.Size, &d.SHA256, &d.UploadedAt)
+	return d, err
+}
+
+const documentColumns = "id, registration_id, doc_type, filename, content_type, size, sha256, uploaded_at"
+
+func loadAgreementStatus(ctx context.Context, tx pgx.Tx, regID int, status string) (agreementStatus, error) {
+	report := agreementStatus{RegistrationID: regID, Status: status, Signatures: map[string]*Signature{}, Missing: []string{}}
+
+	// Latest upload of each type
+	rows, err := tx.Query(ctx,
+		"SELECT DISTINCT ON (doc_type) "+documentColumns+" FROM registration_documents WHERE registration_id=$1 ORDER BY doc_type, id DESC",
+		regID)
+	if err != nil {
+		return report, err
+	}
+	latest, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Document, error) {
+		return scanDocument(row)
+	})
+	if err != nil {
+		return report, err
+	}
+	byType := map[string]*Document{}
+	for i := range latest {
+		byType[latest[i].Type] = &latest[i]
+	}
+
+	for _, t := range requiredDocuments() {
+		report.Documents = append(report.Documents, requiredDocument{Type: t, Document: byType[t]})
+		if byType[t] == nil {
+			report.Missing = append(report.Missing, "document "+t)
+		}
+	}
+
+	if agreement := byType[agreementDocument]; agreement != nil {
+		rows, err := tx.Query(ctx, "SELECT party, signed_by, signed_at, document_id FROM agreement_signatures WHERE document_id=$1", agreement.ID)
+		if err != nil {
+			return report, err
+		}
+		signatures, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Signature, error) {
+			var s Signature
+			err := row.Scan(&s.Party, &s.SignedBy, &s.SignedAt, &s.DocumentID)
+			return s, err
+		})
+		if err != nil {
+			return report, err
+		}
+		for i := range signatures {
+			report.Signatures[signatures[i].Party] = &signatures[i]
+		}
+	}
+	for _, party := range signatureParties {
+		if report.Signatures[party] == nil {
+			report.Missing = append(report.Missing, "signature "+party)
+		}
+	}
+
+	report.Complete = len(report.Missing) == 0
+	return report, nil
+}
+
+// POST /internship/{id}/documents?type=<type> (multipart/form-data, file in "file")
+func uploadDocument(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	docType := strings.ToLower(r.URL.Query().Get("type"))
+	if !documentTypePattern.MatchString(docType) {
+		http.Error(w, "Invalid document type", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 20<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "A file is required: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	status, _, err := lockRegistrationForDocuments(ctx, tx, id)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Registration not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !acceptsDocuments(status) {
+		http.Error(w, fmt.Sprintf("Documents can't be uploaded for a %s registration", status), http.StatusConflict)
+		return
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	key := fmt.Sprintf("registrations/%d/%s", id, hex.EncodeToString(random))
+	hash := sha256.New()
+	counter := &countingReader{r: io.TeeReader(file, hash)}
+	if err := documents.Put(ctx, key, counter); err != nil {
+		http.Error(w, "Failed to store document: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	doc, err := scanDocument(tx.QueryRow(ctx,
+		`INSERT INTO registration_documents (registration_id, doc_type, filename, content_type, size, sha256, storage_key)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING `+documentColumns,
+		id, docType, header.Filename, contentType, counter.n, hex.EncodeToString(hash.Sum(nil)), key))
+	if err == nil {
+		err = tx.Commit(ctx)
+	}
+	if err != nil {
+		documents.Delete(ctx, key)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, http.StatusCreated, doc)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// GET /internship/{id}/documents
+// Every upload, including the ones replaced by a later upload of the same type.
+func getDocuments(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := db.Query(context.Background(),
+		"SELECT "+documentColumns+" FROM registration_documents WHERE registration_id=$1 ORDER BY id", id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	docs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Document, error) {
+		return scanDocument(row)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if docs == nil {
+		docs = []Document{}
+	}
+
+	jsonResponse(w, http.StatusOK, docs)
+}
+
+// GET /internship/{id}/documents/{docId}
+func downloadDocument(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	docID, err := strconv.Atoi(chi.URLParam(r, "docId"))
+	if err != nil {
+		http.Error(w, "Invalid document ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.Background()
+	var filename, contentType, key string
+	err = db.QueryRow(ctx,
+		"SELECT filename, content_type, storage_key FROM registration_documents WHERE id=$1 AND registration_id=$2",
+		docID, id).Scan(&filename, &contentType, &key)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Document not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	content, err := documents.Get(ctx, key)
+	if errors.Is(err, errDocumentNotFound) {
+		http.Error(w, "Document content is missing", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	io.Copy(w, content)
+}
+
+// GET /internship/{id}/agreement
+func getAgreement(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	var status string
+	err = tx.QueryRow(ctx, "SELECT status FROM registrations WHERE id=$1", id).Scan(&status)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Registration not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	report, err := loadAgreementStatus(ctx, tx, id, status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, report)
+}
+
+// POST /internship/{id}/signatures
+// Records the signature of one party on the current agreement.
+func signAgreement(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var input struct {
+		Party    string `json:"party"`
+		SignedBy string `json:"signedBy"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	validParty := false
+	for _, p := range signatureParties {
+		validParty = validParty || p == input.Party
+	}
+	if !validParty {
+		http.Error(w, "Invalid party, expected student, company or school", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(input.SignedBy) == "" {
+		http.Error(w, "signedBy is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	status, _, err := lockRegistrationForDocuments(ctx, tx, id)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Registration not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !acceptsDocuments(status) {
+		http.Error(w, fmt.Sprintf("The agreement of a %s registration can't be signed", status), http.StatusConflict)
+		return
+	}
+
+	var agreementID int
+	err = tx.QueryRow(ctx,
+		"SELECT id FROM registration_documents WHERE registration_id=$1 AND doc_type=$2 ORDER BY id DESC LIMIT 1",
+		id, agreementDocument).Scan(&agreementID)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Upload the agreement before signing it", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tag, err := tx.Exec(ctx,
+		"INSERT INTO agreement_signatures (document_id, party, signed_by) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+		agreementID, input.Party, strings.TrimSpace(input.SignedBy))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		http.Error(w, "The agreement is already signed by the "+input.Party, http.StatusConflict)
+		return
+	}
+
+	report, err := loadAgreementStatus(ctx, tx, id, status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, http.StatusCreated, report)
+}
+
+// POST /internship/{id}/confirm
+// 409 with the agreement checklist while documents or signatures are missing.
+func confirmRegistration(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	status, studentID, err := lockRegistrationForDocuments(ctx, tx, id)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Registration not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if status != statusApproved {
+		http.Error(w, fmt.Sprintf("Only approved registrations can be confirmed, this one is %s", status), http.StatusConflict)
+		return
+	}
+
+	report, err := loadAgreementStatus(ctx, tx, id, status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !report.Complete {
+		jsonResponse(w, http.StatusConflict, report)
+		return
+	}
+
+	message := "Internship confirmed, the agreement is signed by all parties"
+	if err := setRegistrationStatus(ctx, tx, id, statusConfirmed, message); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := notifyStudent(ctx, tx, studentID, id, message); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	reg, err := loadRegistration(ctx, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonResponse(w, http.StatusOK, reg)
+}
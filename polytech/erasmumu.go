@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+var (
+	errOfferNotFound = errors.New("offer not found")
+	errNoSeatsLeft   = errors.New("no seats left")
+)
+
+// Client used for every call to Erasmumu, so a hung service can't pin a handler forever.
+var erasmumuHTTP = &http.Client{Timeout: 5 * time.Second}
+
+// Base URL of the Erasmumu service
+func erasmumuURL() string {
+	// Use environment variable for service URL if available, else default to docker service name
+	if os.Getenv("ERASMUMU_URL") != "" {
+		return os.Getenv("ERASMUMU_URL")
+	}
+	return "http://erasmumu:8080"
+}
+
+// fetchOffer performs a conditional GET /offer/{id} on Erasmumu.
+// When etag is not empty it is sent as If-None-Match, and notModified reports a 304 answer
+// (offer is then nil and the caller keeps its copy).
+func fetchOffer(ctx context.Context, id string, etag string) (offer *ErasmumuOffer, newETag string, notModified bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/offer/%s", erasmumuURL(), id), nil)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := erasmumuHTTP.Do(req)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to contact Erasmumu service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, etag, true, nil
+	case http.StatusNotFound:
+		return nil, "", false, errOfferNotFound
+	case http.StatusOK:
+	default:
+		return nil, "", false, fmt.Errorf("erasmumu answered %s", resp.Status)
+	}
+
+	var o ErasmumuOffer
+	if err := json.NewDecoder(resp.Body).Decode(&o); err != nil {
+		return nil, "", false, fmt.Errorf("failed to parse offer: %w", err)
+	}
+	return &o, resp.Header.Get("ETag"), false, nil
+}
+
+// fetchOffers lists the available offers of a domain (GET /offer?domain=)
+func fetchOffers(ctx context.Context, domain string) ([]ErasmumuOffer, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/offer?domain=%s", erasmumuURL(), url.QueryEscape(domain)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := erasmumuHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to contact Erasmumu service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("erasmumu answered %s", resp.Status)
+	}
+
+	var list []ErasmumuOffer
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to parse offers: %w", err)
+	}
+	return list, nil
+}
+
+// reserveSeat holds a seat of the offer for key. Erasmumu treats the call as idempotent per key,
+// so it is safe to retry after a timeout or a crash.
+func reserveSeat(ctx context.Context, offerID, key string) error {
+	return seatRequest(ctx, http.MethodPut, offerID, key)
+}
+
+// releaseSeat gives back the seat held by key. Releasing a seat that isn't held succeeds.
+func releaseSeat(ctx context.Context, offerID, key string) error {
+	return seatRequest(ctx, http.MethodDelete, offerID, key)
+}
+
+func seatRequest(ctx context.Context, method, offerID, key string) error {
+	req, err := http.NewRequestWithContext(ctx, method,
+		fmt.Sprintf("%s/offer/%s/reservations/%s", erasmumuURL(), url.PathEscape(offerID), url.PathEscape(key)), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := erasmumuHTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to contact Erasmumu service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errOfferNotFound
+	case resp.StatusCode == http.StatusConflict:
+		return errNoSeatsLeft
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("erasmumu answered %s", resp.Status)
+	}
+	return nil
+}
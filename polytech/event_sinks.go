@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// EventSink is a destination of outbox events.
+// Deliver must be safe to call again with an event it already accepted (at-least-once delivery).
+type EventSink interface {
+	Name() string
+	Deliver(ctx context.Context, event OutboxEvent) error
+}
+
+// webhookSink POSTs each event as JSON, any 2xx answer acknowledges it
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookSink(url string) *webhookSink {
+	return &webhookSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *webhookSink) Name() string { return "webhook " + s.url }
+
+func (s *webhookSink) Deliver(ctx context.Context, event OutboxEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", fmt.Sprint(event.ID))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("answered %s", resp.Status)
+	}
+	return nil
+}
+
+// fileSink appends each event as one JSON line (NDJSON)
+type fileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newFileSink(path string) *fileSink {
+	return &fileSink{path: path}
+}
+
+func (s *fileSink) Name() string { return "file " + s.path }
+
+func (s *fileSink) Deliver(ctx context.Context, event OutboxEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// eventBus dispatches events to in-process subscribers.
+// Subscribers run synchronously in the relay goroutine; an error makes the event retried.
+type eventBus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]func(context.Context, OutboxEvent) error // by event type, "*" for all
+}
+
+// Global in-process bus, always registered as an outbox sink
+var events = newEventBus()
+
+func newEventBus() *eventBus {
+	return &eventBus{subscribers: map[string][]func(context.Context, OutboxEvent) error{}}
+}
+
+// Subscribe registers handler for an event type ("*" for every event)
+func (b *eventBus) Subscribe(eventType string, handler func(context.Context, OutboxEvent) error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[eventType] = append(b.subscribers[eventType], handler)
+}
+
+func (b *eventBus) Name() string { return "bus" }
+
+func (b *eventBus) Deliver(ctx context.Context, event OutboxEvent) error {
+	b.mu.RLock()
+	handlers := append(append([]func(context.Context, OutboxEvent) error{}, b.subscribers[event.Type]...), b.subscribers["*"]...)
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		if err := h(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
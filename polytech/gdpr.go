@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// Data subject rights: a student can get all their data (export) and have it erased.
+// Erasure anonymizes the personal fields but keeps registrations, which we need for statistics.
+// The content of uploaded documents is deleted, their metadata kept without the filenames.
+
+type studentExport struct {
+	ExportedAt    time.Time              `json:"exportedAt"`
+	Student       exportedStudent        `json:"student"`
+	Registrations []exportedRegistration `json:"registrations"`
+	Notifications []Notification         `json:"notifications"`
+	Documents     []Document             `json:"documents"`  // metadata, the content is downloaded per document
+	Signatures    []Signature            `json:"signatures"` // of the agreements of the registrations
+	AuditLog      []AuditEntry           `json:"auditLog"`
+	Events        []OutboxEvent          `json:"events"`
+}
+
+type exportedStudent struct {
+	Student
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	ErasedAt  *time.Time `json:"erasedAt,omitempty"`
+}
+
+type exportedRegistration struct {
+	Registration
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// GET /student/{id}/export
+func exportStudent(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	// Repeatable read: every part of the bundle comes from the same snapshot
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	export := studentExport{ExportedAt: time.Now().UTC()}
+	export.Student.Student, err = scanStudent(tx.QueryRow(ctx, "SELECT "+studentColumns+" FROM students WHERE id=$1", id))
+	if err == nil {
+		s := &export.Student
+		err = tx.QueryRow(ctx, "SELECT created_at, updated_at, deleted_at, erased_at FROM students WHERE id=$1", id).
+			Scan(&s.CreatedAt, &s.UpdatedAt, &s.DeletedAt, &s.ErasedAt)
+	}
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT id, student_id, offer_id, status, message, created_at, updated_at FROM registrations WHERE student_id=$1 ORDER BY id", id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	export.Registrations, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (exportedRegistration, error) {
+		var reg exportedRegistration
+		err := row.Scan(&reg.ID, &reg.StudentID, &reg.OfferID, &reg.Status, &reg.Message, &reg.CreatedAt, &reg.UpdatedAt)
+		return reg, err
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rows, err = tx.Query(ctx,
+		"SELECT id, student_id, registration_id, message, created_at FROM notifications WHERE student_id=$1 ORDER BY id", id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	export.Notifications, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (Notification, error) {
+		var n Notification
+		err := row.Scan(&n.ID, &n.StudentID, &n.RegistrationID, &n.Message, &n.CreatedAt)
+		return n, err
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rows, err = tx.Query(ctx,
+		"SELECT "+documentColumns+" FROM registration_documents WHERE registration_id IN (SELECT id FROM registrations WHERE student_id=$1) ORDER BY id", id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	export.Documents, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (Document, error) {
+		return scanDocument(row)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT s.party, s.signed_by, s.signed_at, s.document_id FROM agreement_signatures s
+		JOIN registration_documents d ON d.id = s.document_id
+		WHERE d.registration_id IN (SELECT id FROM registrations WHERE student_id=$1)
+		ORDER BY s.document_id, s.party`, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	export.Signatures, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (Signature, error) {
+		var s Signature
+		err := row.Scan(&s.Party, &s.SignedBy, &s.SignedAt, &s.DocumentID)
+		return s, err
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	export.AuditLog, err = auditEntriesForStudent(ctx, tx, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Domain events about the student and their registrations
+	rows, err = tx.Query(ctx, `
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at FROM outbox
+		WHERE (aggregate_type=$1 AND aggregate_id=$2)
+		   OR (aggregate_type=$3 AND aggregate_id IN (SELECT id::text FROM registrations WHERE student_id=$4))
+		ORDER BY id`,
+		aggregateStudent, strconv.Itoa(id), aggregateRegistration, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	export.Events, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (OutboxEvent, error) {
+		var e OutboxEvent
+		err := row.Scan(&e.ID, &e.AggregateType, &e.AggregateID, &e.Type, &e.Payload, &e.CreatedAt)
+		return e, err
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := recordAudit(ctx, tx, id, auditStudentExported, map[string]interface{}{}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="student-%d.json"`, id))
+	jsonResponse(w, http.StatusOK, export)
+}
+
+// POST /student/{id}/erase
+func eraseStudent(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	// Erased (or deleted) students can be erased again, e.g. after new personal data leaked in
+	var found int
+	err = tx.QueryRow(ctx, "SELECT id FROM students WHERE id=$1 FOR UPDATE", id).Scan(&found)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := anonymizeStudent(ctx, tx, id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err := tx.Exec(ctx, "UPDATE students SET erased_at=now() WHERE id=$1", id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Notifications are personal messages, registrations and their decisions are kept
+	notifications, err := tx.Exec(ctx, "DELETE FROM notifications WHERE student_id=$1", id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Uploaded documents: their content is deleted once committed, their filenames (replaced by
+	// their type) and the student's signatures now. The other signatories aren't erased.
+	rows, err := tx.Query(ctx,
+		"SELECT storage_key FROM registration_documents WHERE registration_id IN (SELECT id FROM registrations WHERE student_id=$1) ORDER BY id", id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	storageKeys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err := tx.Exec(ctx,
+		"UPDATE registration_documents SET filename=doc_type WHERE registration_id IN (SELECT id FROM registrations WHERE student_id=$1)", id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	signatures, err := tx.Exec(ctx, `
+		UPDATE agreement_signatures SET signed_by='' WHERE party='student' AND document_id IN (
+			SELECT d.id FROM registration_documents d JOIN registrations r ON r.id = d.registration_id WHERE r.student_id=$1)`, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Past events carried the personal fields too
+	events, err := tx.Exec(ctx,
+		"UPDATE outbox SET payload = payload - $1::text[] WHERE aggregate_type=$2 AND aggregate_id=$3",
+		anonymizedFields, aggregateStudent, strconv.Itoa(id))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	details := map[string]interface{}{
+		"fields":               anonymizedFields,
+		"notificationsDeleted": notifications.RowsAffected(),
+		"eventsScrubbed":       events.RowsAffected(),
+		"documentsDeleted":     len(storageKeys),
+		"signaturesScrubbed":   signatures.RowsAffected(),
+	}
+	if err := recordAudit(ctx, tx, id, auditStudentErased, details); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The keys are kept, a document that couldn't be deleted is deleted by erasing again
+	failed := 0
+	for _, key := range storageKeys {
+		if err := documents.Delete(ctx, key); err != nil {
+			fmt.Printf("Erasure of student %d: deleting document %s: %v\n", id, key, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		http.Error(w, fmt.Sprintf("Student erased, but %d documents could not be deleted, erase again to retry", failed), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
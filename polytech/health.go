@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"polytech/client"
+)
+
+// readiness reports whether polytech can serve requests, for the orchestrator's readiness probe
+type readiness struct {
+	mi8 *client.MI8Client
+}
+
+type readinessReport struct {
+	Ready    bool `json:"ready"`
+	Database bool `json:"database"`
+	MI8      bool `json:"mi8"`
+}
+
+// GET /ready, 503 while the database or MI8 is unreachable
+func (h *readiness) ready(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	report := readinessReport{
+		Database: db.Ping(ctx) == nil,
+		MI8:      h.mi8.Ready(),
+	}
+	report.Ready = report.Database && report.MI8
+
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	jsonResponse(w, status, report)
+}
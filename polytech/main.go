@@ -6,12 +6,13 @@ import (
 
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jackc/pgx/v5/pgxpool"
-    "polytech/client" 
+	"polytech/client"
 )
 
 func main() {
@@ -30,53 +31,153 @@ func main() {
 	}
 	defer db.Close()
 
-	// Create table if not exists
-	_, err = db.Exec(context.Background(), `
-		CREATE TABLE IF NOT EXISTS students (
-			id SERIAL PRIMARY KEY,
-			firstname TEXT NOT NULL,
-			name TEXT NOT NULL,
-			domain TEXT NOT NULL
-		);
-		CREATE TABLE IF NOT EXISTS registrations (
-			id SERIAL PRIMARY KEY,
-			student_id INT NOT NULL,
-			offer_id TEXT NOT NULL,
-			status TEXT NOT NULL,
-			message TEXT
-		);
-	`)
+	// `main migrate ...` only manages the schema
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(context.Background(), db, os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Migration failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Bring the schema up to date, unless migrations are run separately
+	if os.Getenv("AUTO_MIGRATE") != "false" {
+		if err := migrateUp(context.Background(), db, 0); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to migrate database: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Offer cache in front of Erasmumu
+	offers = newOfferCache(
+		envDuration("OFFER_CACHE_TTL", 30*time.Second),
+		envDuration("OFFER_CACHE_STALE_TTL", 5*time.Minute),
+		envInt("OFFER_CACHE_MAX_ENTRIES", 1000),
+	)
+
+	// Resume seat reservations interrupted by a crash
+	go runSagaRecovery(envDuration("SAGA_RETRY_INTERVAL", time.Minute))
+
+	// Deliver domain events recorded in the outbox
+	sinks := []EventSink{events}
+	if u := os.Getenv("OUTBOX_WEBHOOK_URL"); u != "" {
+		sinks = append(sinks, newWebhookSink(u))
+	}
+	if f := os.Getenv("OUTBOX_FILE"); f != "" {
+		sinks = append(sinks, newFileSink(f))
+	}
+	relay := &outboxRelay{
+		sinks:     sinks,
+		interval:  envDuration("OUTBOX_RELAY_INTERVAL", time.Second),
+		batchSize: envInt("OUTBOX_BATCH_SIZE", 100),
+	}
+	go relay.run(context.Background())
+
+	// Re-check approved registrations against their offers, in case a change notification was lost
+	if interval := envDuration("OFFER_REVALIDATE_INTERVAL", 10*time.Minute); interval > 0 {
+		go runOfferRevalidation(interval)
+	}
+
+	// Content of the documents uploaded for registrations
+	documentDir := os.Getenv("DOCUMENT_DIR")
+	if documentDir == "" {
+		documentDir = "documents"
+	}
+	if documents, err = newLocalDocumentStore(documentDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to open document store: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Close the selections of offers in selection mode once their deadline passed
+	go runSelectionScheduler(envDuration("SELECTION_INTERVAL", time.Minute))
+
+	// City news and scores. The connection is kept for the life of the process, MI8 being
+	// down at startup or later only fails the calls needing it (and readiness).
+	mi8Addr := os.Getenv("MI8_ADDR")
+	if mi8Addr == "" {
+		mi8Addr = "mi8:50051"
+	}
+	mi8, err := client.NewMI8Client(client.Config{
+		Addr:          mi8Addr,
+		CallTimeout:   envDuration("MI8_TIMEOUT", 3*time.Second),
+		KeepaliveTime: envDuration("MI8_KEEPALIVE", 30*time.Second),
+		MaxBackoff:    envDuration("MI8_MAX_BACKOFF", 30*time.Second),
+		MaxAttempts:   envInt("MI8_MAX_ATTEMPTS", 3),
+	})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Unable to create table: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Unable to create MI8 client: %v\n", err)
 		os.Exit(1)
 	}
+	defer mi8.Close()
+	cities := &mi8Handlers{mi8: mi8}
 
 	// 2. Setup Router
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 
 	r.Post("/student", createStudent)
+	r.Post("/student/import", importStudents)
+	r.Get("/student/export", exportStudents)
 	r.Get("/student/{id}", getStudent) // chi uses {param} syntax
 	r.Get("/student", getStudents)
 	r.Put("/student/{id}", updateStudent)
 	r.Delete("/student/{id}", deleteStudent)
+	r.Get("/student/{id}/notifications", getStudentNotifications)
+	r.Get("/student/{id}/export", exportStudent)
+	r.Post("/student/{id}/erase", eraseStudent)
+	r.Get("/student/{id}/recommendations", cities.getRecommendations)
 
 	r.Post("/internship", registerInternship)
 	r.Get("/internship/{id}", getRegistration)
+	r.Put("/internship/{id}/priority", setAdvisorPriority)
+	r.Post("/internship/{id}/withdraw", withdrawRegistration)
+	r.Post("/internship/{id}/documents", uploadDocument)
+	r.Get("/internship/{id}/documents", getDocuments)
+	r.Get("/internship/{id}/documents/{docId}", downloadDocument)
+	r.Get("/internship/{id}/agreement", getAgreement)
+	r.Post("/internship/{id}/signatures", signAgreement)
+	r.Post("/internship/{id}/confirm", confirmRegistration)
+
+	r.Put("/offer/{offerId}/selection", configureSelection)
+	r.Get("/offer/{offerId}/candidates", getCandidates)
+	r.Get("/offer/{offerId}/waitlist", getWaitlist)
+
+	r.With(requireWebhookSignature).Post("/events/offer", handleOfferEvent)
 
-    // Start MI8 Client verification
-    go func() {
-        time.Sleep(5 * time.Second) // Wait for MI8 to start
-        fmt.Println("Connecting to MI8...")
-        client, err := client.NewMI8Client("mi8:50051")
-        if err != nil {
-            fmt.Printf("Failed to connect to MI8: %v\n", err)
-            return
-        }
-        defer client.Close()
-        client.GetLatestNews(5)
-    }()
+	r.Get("/city/top", cities.getTopCities)
+	r.Get("/city/{name}/news", cities.getCityNews)
+	r.Get("/city/{name}/score", cities.getCityScore)
+
+	r.Get("/ready", (&readiness{mi8: mi8}).ready)
 
 	fmt.Println("Server starting on :8080")
 	http.ListenAndServe(":8080", r)
 }
+
+// envDuration reads a duration (e.g. "30s") from the environment, falling back to def
+func envDuration(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid %s=%q, using %s\n", name, v, def)
+		return def
+	}
+	return d
+}
+
+// envInt reads an integer from the environment, falling back to def
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid %s=%q, using %d\n", name, v, def)
+		return def
+	}
+	return i
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS registration_sagas;
+DROP TABLE IF EXISTS registrations;
+DROP TABLE IF EXISTS students;
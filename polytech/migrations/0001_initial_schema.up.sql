@@ -0,0 +1,45 @@
+-- Tables previously created at startup. IF NOT EXISTS lets databases created
+-- before migrations existed adopt this version without changes.
+CREATE TABLE IF NOT EXISTS students (
+	id SERIAL PRIMARY KEY,
+	firstname TEXT NOT NULL,
+	name TEXT NOT NULL,
+	domain TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS registrations (
+	id SERIAL PRIMARY KEY,
+	student_id INT NOT NULL,
+	offer_id TEXT NOT NULL,
+	status TEXT NOT NULL,
+	message TEXT
+);
+
+CREATE TABLE IF NOT EXISTS registration_sagas (
+	registration_id INT PRIMARY KEY,
+	offer_id TEXT NOT NULL,
+	state TEXT NOT NULL,
+	last_error TEXT,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS outbox (
+	id BIGSERIAL PRIMARY KEY,
+	aggregate_type TEXT NOT NULL,
+	aggregate_id TEXT NOT NULL,
+	event_type TEXT NOT NULL,
+	payload JSONB NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	delivered_at TIMESTAMPTZ,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT
+);
+CREATE INDEX IF NOT EXISTS outbox_pending ON outbox (id) WHERE delivered_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notifications (
+	id SERIAL PRIMARY KEY,
+	student_id INT NOT NULL,
+	registration_id INT,
+	message TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
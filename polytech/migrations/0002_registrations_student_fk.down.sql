@@ -0,0 +1 @@
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_student_id_fkey;
@@ -0,0 +1,5 @@
+-- NOT VALID: registrations orphaned before this migration are left alone,
+-- every new or updated row must reference an existing student.
+ALTER TABLE registrations
+	ADD CONSTRAINT registrations_student_id_fkey
+	FOREIGN KEY (student_id) REFERENCES students (id) NOT VALID;
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS registrations_updated_at ON registrations;
+DROP TRIGGER IF EXISTS students_updated_at ON students;
+DROP FUNCTION IF EXISTS set_updated_at();
+
+ALTER TABLE registrations
+	DROP COLUMN IF EXISTS updated_at,
+	DROP COLUMN IF EXISTS created_at;
+
+ALTER TABLE students
+	DROP COLUMN IF EXISTS updated_at,
+	DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,21 @@
+ALTER TABLE students
+	ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+ALTER TABLE registrations
+	ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- Keep updated_at current without every UPDATE statement having to set it
+CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+	NEW.updated_at = now();
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER students_updated_at BEFORE UPDATE ON students
+	FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER registrations_updated_at BEFORE UPDATE ON registrations
+	FOR EACH ROW EXECUTE FUNCTION set_updated_at();
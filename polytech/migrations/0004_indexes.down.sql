@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS registrations_student_id_idx;
+DROP INDEX IF EXISTS students_domain_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX students_domain_idx ON students (domain);
+CREATE INDEX registrations_student_id_idx ON registrations (student_id);
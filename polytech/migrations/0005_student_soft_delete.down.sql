@@ -0,0 +1 @@
+ALTER TABLE students DROP COLUMN IF EXISTS deleted_at;
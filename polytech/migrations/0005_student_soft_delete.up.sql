@@ -0,0 +1,3 @@
+-- Deleted students are kept (and possibly anonymized) so their registrations
+-- still reference an existing row.
+ALTER TABLE students ADD COLUMN deleted_at TIMESTAMPTZ;
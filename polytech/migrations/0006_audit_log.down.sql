@@ -0,0 +1,2 @@
+ALTER TABLE students DROP COLUMN IF EXISTS erased_at;
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,11 @@
+CREATE TABLE audit_log (
+	id BIGSERIAL PRIMARY KEY,
+	student_id INT REFERENCES students (id),
+	registration_id INT REFERENCES registrations (id),
+	action TEXT NOT NULL,
+	details JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX audit_log_student_id_idx ON audit_log (student_id);
+
+ALTER TABLE students ADD COLUMN erased_at TIMESTAMPTZ;
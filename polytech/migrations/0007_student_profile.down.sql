@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS students_email_key;
+
+ALTER TABLE students
+	DROP COLUMN IF EXISTS languages,
+	DROP COLUMN IF EXISTS cohort,
+	DROP COLUMN IF EXISTS study_year,
+	DROP COLUMN IF EXISTS email;
@@ -0,0 +1,8 @@
+ALTER TABLE students
+	ADD COLUMN email TEXT,
+	ADD COLUMN study_year INT,
+	ADD COLUMN cohort TEXT,
+	ADD COLUMN languages JSONB NOT NULL DEFAULT '[]';
+
+-- Deleted students don't keep their address reserved
+CREATE UNIQUE INDEX students_email_key ON students (lower(email)) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS students_student_number_key;
+ALTER TABLE students DROP COLUMN IF EXISTS student_number;
@@ -0,0 +1,3 @@
+-- Registrar's student number, key of the yearly CSV import together with email
+ALTER TABLE students ADD COLUMN student_number TEXT;
+CREATE UNIQUE INDEX students_student_number_key ON students (student_number) WHERE deleted_at IS NULL;
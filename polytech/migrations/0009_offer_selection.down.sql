@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS registrations_offer_id_idx;
+ALTER TABLE registrations DROP COLUMN IF EXISTS advisor_priority;
+DROP TABLE IF EXISTS offer_selections;
@@ -0,0 +1,17 @@
+-- Offers whose registrations are collected until a deadline, then ranked (see selection.go)
+CREATE TABLE offer_selections (
+	offer_id TEXT PRIMARY KEY,
+	deadline TIMESTAMPTZ NOT NULL,
+	seats INT NOT NULL CHECK (seats > 0),
+	weights JSONB NOT NULL DEFAULT '{}',
+	closed_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE TRIGGER offer_selections_updated_at BEFORE UPDATE ON offer_selections
+	FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE INDEX offer_selections_open_idx ON offer_selections (deadline) WHERE closed_at IS NULL;
+
+-- Set by the student's advisor, one of the ranking criteria
+ALTER TABLE registrations ADD COLUMN advisor_priority INT NOT NULL DEFAULT 0;
+CREATE INDEX registrations_offer_id_idx ON registrations (offer_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS registrations_waitlist_idx;
+ALTER TABLE registrations DROP COLUMN IF EXISTS waitlist_position;
+DROP SEQUENCE IF EXISTS registrations_waitlist_seq;
@@ -0,0 +1,4 @@
+-- Order of waitlisted registrations within their offer, lowest first (see waitlist.go)
+CREATE SEQUENCE registrations_waitlist_seq;
+ALTER TABLE registrations ADD COLUMN waitlist_position BIGINT;
+CREATE INDEX registrations_waitlist_idx ON registrations (offer_id, waitlist_position) WHERE status = 'waitlisted';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS agreement_signatures;
+DROP TABLE IF EXISTS registration_documents;
@@ -0,0 +1,24 @@
+-- Documents uploaded for an approved registration (see documents.go). A type can be uploaded
+-- again, the latest upload is the current one.
+CREATE TABLE registration_documents (
+	id SERIAL PRIMARY KEY,
+	registration_id INT NOT NULL REFERENCES registrations (id),
+	doc_type TEXT NOT NULL,
+	filename TEXT NOT NULL,
+	content_type TEXT NOT NULL,
+	size BIGINT NOT NULL,
+	sha256 TEXT NOT NULL,
+	storage_key TEXT NOT NULL,
+	uploaded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX registration_documents_registration_id_idx ON registration_documents (registration_id, doc_type);
+
+-- Signatures of the tripartite agreement. They apply to one uploaded version of the agreement,
+-- uploading a new one needs new signatures.
+CREATE TABLE agreement_signatures (
+	document_id INT NOT NULL REFERENCES registration_documents (id),
+	party TEXT NOT NULL CHECK (party IN ('student', 'company', 'school')),
+	signed_by TEXT NOT NULL,
+	signed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (document_id, party)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS outbox_pending_aggregate;
+ALTER TABLE outbox DROP COLUMN IF EXISTS next_attempt_at;
@@ -0,0 +1,4 @@
+-- Failed outbox events are retried with an exponential backoff (see outbox.go), so they don't
+-- hold the head of the queue
+ALTER TABLE outbox ADD COLUMN next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now();
+CREATE INDEX outbox_pending_aggregate ON outbox (aggregate_type, aggregate_id, id) WHERE delivered_at IS NULL;
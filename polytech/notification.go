@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+type Notification struct {
+	ID             int       `json:"id"`
+	StudentID      int       `json:"studentId"`
+	RegistrationID *int      `json:"registrationId,omitempty"`
+	Message        string    `json:"message"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// notifyStudent stores a notification for the student inside the caller's transaction
+func notifyStudent(ctx context.Context, tx pgx.Tx, studentID int, registrationID int, message string) error {
+	_, err := tx.Exec(ctx,
+		"INSERT INTO notifications (student_id, registration_id, message) VALUES ($1, $2, $3)",
+		studentID, registrationID, message)
+	return err
+}
+
+// GET /student/{id}/notifications
+func getStudentNotifications(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := db.Query(context.Background(),
+		"SELECT id, student_id, registration_id, message, created_at FROM notifications WHERE student_id=$1 ORDER BY id DESC", id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	notifications := []Notification{}
+	for rows.Next() {
+		var n Notification
+		if err := rows.Scan(&n.ID, &n.StudentID, &n.RegistrationID, &n.Message, &n.CreatedAt); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		notifications = append(notifications, n)
+	}
+
+	jsonResponse(w, http.StatusOK, notifications)
+}
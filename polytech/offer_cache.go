@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Read-through cache of Erasmumu offers.
+//
+// An entry is fresh for ttl. Once it is older, it is revalidated with a conditional request
+// (If-None-Match) so unchanged offers cost a 304 instead of a full body. When staleTTL is set
+// (stale-while-revalidate mode), an expired entry keeps being served for up to staleTTL while a
+// background refresh runs, so registrations keep working if Erasmumu is briefly down.
+type offerCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	staleTTL   time.Duration
+	maxEntries int
+	entries    map[string]*offerCacheEntry
+	lru        *list.List // front = most recently used, values are offer IDs
+
+	fetch func(ctx context.Context, id string, etag string) (*ErasmumuOffer, string, bool, error)
+}
+
+type offerCacheEntry struct {
+	offer      ErasmumuOffer
+	etag       string
+	fetchedAt  time.Time
+	refreshing bool
+	elem       *list.Element
+}
+
+// Global offer cache, configured in main
+var offers *offerCache
+
+func newOfferCache(ttl, staleTTL time.Duration, maxEntries int) *offerCache {
+	return &offerCache{
+		ttl:        ttl,
+		staleTTL:   staleTTL,
+		maxEntries: maxEntries,
+		entries:    map[string]*offerCacheEntry{},
+		lru:        list.New(),
+		fetch:      fetchOffer,
+	}
+}
+
+// Get returns the offer, from the cache when possible.
+// It returns errOfferNotFound when Erasmumu doesn't know (or no longer exposes) the offer.
+func (c *offerCache) Get(ctx context.Context, id string) (ErasmumuOffer, error) {
+	c.mu.Lock()
+	etag := ""
+	if e, ok := c.entries[id]; ok {
+		c.lru.MoveToFront(e.elem)
+		age := time.Since(e.fetchedAt)
+		if age < c.ttl {
+			offer := e.offer
+			c.mu.Unlock()
+			return offer, nil
+		}
+		if age < c.ttl+c.staleTTL {
+			if !e.refreshing {
+				e.refreshing = true
+				go c.revalidate(id, e.etag)
+			}
+			offer := e.offer
+			c.mu.Unlock()
+			return offer, nil
+		}
+		etag = e.etag
+	}
+	c.mu.Unlock()
+
+	return c.load(ctx, id, etag)
+}
+
+// Invalidate drops the cached copy of an offer, the next Get goes to Erasmumu.
+func (c *offerCache) Invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove(id)
+}
+
+func (c *offerCache) load(ctx context.Context, id string, etag string) (ErasmumuOffer, error) {
+	offer, newETag, notModified, err := c.fetch(ctx, id, etag)
+	if errors.Is(err, errOfferNotFound) {
+		c.Invalidate(id)
+		return ErasmumuOffer{}, err
+	}
+	if err != nil {
+		return ErasmumuOffer{}, err
+	}
+
+	c.mu.Lock()
+	if notModified {
+		if e, ok := c.entries[id]; ok {
+			e.fetchedAt = time.Now()
+			cached := e.offer
+			c.mu.Unlock()
+			return cached, nil
+		}
+		// Evicted while we were asking, fetch the full body again
+		c.mu.Unlock()
+		return c.load(ctx, id, "")
+	}
+	c.store(id, *offer, newETag)
+	c.mu.Unlock()
+
+	return *offer, nil
+}
+
+// revalidate refreshes a stale entry in the background.
+// On failure the stale copy is kept until it falls out of the stale window.
+func (c *offerCache) revalidate(id string, etag string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	offer, newETag, notModified, err := c.fetch(ctx, id, etag)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[id]
+	if !ok {
+		return
+	}
+	e.refreshing = false
+	switch {
+	case errors.Is(err, errOfferNotFound):
+		c.remove(id)
+	case err != nil:
+		fmt.Printf("Offer cache: failed to revalidate offer %s, serving stale copy: %v\n", id, err)
+	case notModified:
+		e.fetchedAt = time.Now()
+	default:
+		e.offer = *offer
+		e.etag = newETag
+		e.fetchedAt = time.Now()
+	}
+}
+
+// store must be called with c.mu held
+func (c *offerCache) store(id string, offer ErasmumuOffer, etag string) {
+	if e, ok := c.entries[id]; ok {
+		e.offer = offer
+		e.etag = etag
+		e.fetchedAt = time.Now()
+		c.lru.MoveToFront(e.elem)
+		return
+	}
+
+	c.entries[id] = &offerCacheEntry{
+		offer:     offer,
+		etag:      etag,
+		fetchedAt: time.Now(),
+		elem:      c.lru.PushFront(id),
+	}
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back().Value.(string))
+	}
+}
+
+// remove must be called with c.mu held
+func (c *offerCache) remove(id string) {
+	if e, ok := c.entries[id]; ok {
+		c.lru.Remove(e.elem)
+		delete(c.entries, id)
+	}
+}
+
+// requireWebhookSignature only lets through requests signed by Erasmumu: X-Webhook-Signature is
+// "sha256=" and the hex HMAC-SHA256 of the body with OFFER_WEBHOOK_SECRET. Without a secret
+// every request is refused.
+func requireWebhookSignature(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := os.Getenv("OFFER_WEBHOOK_SECRET")
+		if secret == "" {
+			http.Error(w, "Webhooks are disabled, OFFER_WEBHOOK_SECRET is not set", http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 64<<10))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		if !hmac.Equal([]byte(r.Header.Get("X-Webhook-Signature")), []byte(expected)) {
+			http.Error(w, "Invalid webhook signature", http.StatusUnauthorized)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// POST /events/offer
+// Change notifications sent by Erasmumu when an offer is updated or deleted, signed (see
+// requireWebhookSignature).
+func handleOfferEvent(w http.ResponseWriter, r *http.Request) {
+	var event struct {
+		Type    string `json:"type"`
+		OfferID string `json:"offerId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if event.OfferID == "" {
+		http.Error(w, "offerId is required", http.StatusBadRequest)
+		return
+	}
+
+	offers.Invalidate(event.OfferID)
+	// Re-check the registrations of the offer in the background, Erasmumu doesn't wait for us
+	go func(offerID string) {
+		if err := revalidateOffer(context.Background(), offerID); err != nil {
+			fmt.Printf("Offer revalidation of %s: %v\n", offerID, err)
+		}
+	}(event.OfferID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Transactional outbox.
+//
+// Domain events are inserted into the outbox table in the same transaction as the change they
+// describe, so an event exists if and only if the change was committed. A relay goroutine then
+// delivers pending events to every sink, in id order, and marks them delivered.
+//
+// Delivery is at-least-once: an event is retried (on every sink) until all sinks accept it, so
+// consumers must deduplicate on the event id. A failed event is retried after a backoff doubling
+// with every attempt, the other events going on meanwhile. Events of one aggregate are delivered
+// in order: when one fails, the later events of the same aggregate wait for it.
+
+// Event types
+const (
+	eventStudentCreated            = "student.created"
+	eventStudentUpdated            = "student.updated"
+	eventStudentDeleted            = "student.deleted"
+	eventRegistrationCreated       = "registration.created"
+	eventRegistrationStatusChanged = "registration.status_changed"
+	eventRegistrationPromoted      = "registration.promoted"
+)
+
+// Aggregate types
+const (
+	aggregateStudent      = "student"
+	aggregateRegistration = "registration"
+)
+
+type OutboxEvent struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	Type          string          `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+// enqueueEvent records an event inside the caller's transaction
+func enqueueEvent(ctx context.Context, tx pgx.Tx, aggregateType string, aggregateID int, eventType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx,
+		"INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4)",
+		aggregateType, strconv.Itoa(aggregateID), eventType, data)
+	return err
+}
+
+// Backoff of failed events, doubling from the first to the longest
+const (
+	outboxFirstRetry   = 5 * time.Second
+	outboxLongestRetry = time.Hour
+)
+
+// Arbitrary key of the advisory lock making a single polytech instance relay at a time
+const outboxLockKey = 7263001
+
+type outboxRelay struct {
+	sinks     []EventSink
+	interval  time.Duration
+	batchSize int
+}
+
+func (r *outboxRelay) run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.relayOnce(ctx); err != nil {
+			fmt.Printf("Outbox relay: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayOnce delivers one batch of pending events
+func (r *outboxRelay) relayOnce(ctx context.Context) error {
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", outboxLockKey).Scan(&locked); err != nil {
+		return err
+	}
+	if !locked {
+		// Another instance is relaying
+		return nil
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", outboxLockKey)
+
+	// Events that are due, unless an earlier event of their aggregate is still waiting to be retried
+	rows, err := conn.Query(ctx, `
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at FROM outbox o
+		WHERE delivered_at IS NULL AND next_attempt_at <= now()
+		  AND NOT EXISTS (
+			SELECT 1 FROM outbox p
+			WHERE p.delivered_at IS NULL AND p.aggregate_type = o.aggregate_type AND p.aggregate_id = o.aggregate_id
+			  AND p.id < o.id AND p.next_attempt_at > now())
+		ORDER BY id LIMIT $1`,
+		r.batchSize)
+	if err != nil {
+		return err
+	}
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (OutboxEvent, error) {
+		var e OutboxEvent
+		err := row.Scan(&e.ID, &e.AggregateType, &e.AggregateID, &e.Type, &e.Payload, &e.CreatedAt)
+		return e, err
+	})
+	if err != nil {
+		return err
+	}
+
+	blocked := map[string]bool{}
+	for _, e := range events {
+		aggregate := e.AggregateType + ":" + e.AggregateID
+		if blocked[aggregate] {
+			continue
+		}
+
+		if err := r.deliver(ctx, e); err != nil {
+			blocked[aggregate] = true
+			if _, err := conn.Exec(ctx, `
+				UPDATE outbox SET attempts=attempts+1, last_error=$1,
+					next_attempt_at=now() + LEAST($2 * power(2, attempts), $3) * interval '1 second'
+				WHERE id=$4`,
+				err.Error(), outboxFirstRetry.Seconds(), outboxLongestRetry.Seconds(), e.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := conn.Exec(ctx, "UPDATE outbox SET delivered_at=now(), attempts=attempts+1, last_error=NULL WHERE id=$1", e.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *outboxRelay) deliver(ctx context.Context, e OutboxEvent) error {
+	for _, sink := range r.sinks {
+		if err := sink.Deliver(ctx, e); err != nil {
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -29,6 +30,7 @@ type News struct {
 	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
 	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
 	Country       string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Id            string                 `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -105,6 +107,101 @@ func (x *News) GetCountry() string {
 	return ""
 }
 
+func (x *News) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetNewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNewsRequest) Reset() {
+	*x = GetNewsRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNewsRequest) ProtoMessage() {}
+
+func (x *GetNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNewsRequest.ProtoReflect.Descriptor instead.
+func (*GetNewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetNewsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteNewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNewsRequest) Reset() {
+	*x = DeleteNewsRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNewsRequest) ProtoMessage() {}
+
+func (x *DeleteNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNewsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeleteNewsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type GetLatestNewsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
@@ -114,7 +211,7 @@ type GetLatestNewsRequest struct {
 
 func (x *GetLatestNewsRequest) Reset() {
 	*x = GetLatestNewsRequest{}
-	mi := &file_proto_mi8_proto_msgTypes[1]
+	mi := &file_proto_mi8_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -126,7 +223,7 @@ func (x *GetLatestNewsRequest) String() string {
 func (*GetLatestNewsRequest) ProtoMessage() {}
 
 func (x *GetLatestNewsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[1]
+	mi := &file_proto_mi8_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -139,7 +236,7 @@ func (x *GetLatestNewsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestNewsRequest.ProtoReflect.Descriptor instead.
 func (*GetLatestNewsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{1}
+	return file_proto_mi8_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetLatestNewsRequest) GetLimit() int32 {
@@ -159,7 +256,7 @@ type GetLatestNewsInCityRequest struct {
 
 func (x *GetLatestNewsInCityRequest) Reset() {
 	*x = GetLatestNewsInCityRequest{}
-	mi := &file_proto_mi8_proto_msgTypes[2]
+	mi := &file_proto_mi8_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -171,7 +268,7 @@ func (x *GetLatestNewsInCityRequest) String() string {
 func (*GetLatestNewsInCityRequest) ProtoMessage() {}
 
 func (x *GetLatestNewsInCityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[2]
+	mi := &file_proto_mi8_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -184,7 +281,7 @@ func (x *GetLatestNewsInCityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestNewsInCityRequest.ProtoReflect.Descriptor instead.
 func (*GetLatestNewsInCityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{2}
+	return file_proto_mi8_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetLatestNewsInCityRequest) GetCity() string {
@@ -210,7 +307,7 @@ type NewsList struct {
 
 func (x *NewsList) Reset() {
 	*x = NewsList{}
-	mi := &file_proto_mi8_proto_msgTypes[3]
+	mi := &file_proto_mi8_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -222,7 +319,7 @@ func (x *NewsList) String() string {
 func (*NewsList) ProtoMessage() {}
 
 func (x *NewsList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_mi8_proto_msgTypes[3]
+	mi := &file_proto_mi8_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -235,7 +332,7 @@ func (x *NewsList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewsList.ProtoReflect.Descriptor instead.
 func (*NewsList) Descriptor() ([]byte, []int) {
-	return file_proto_mi8_proto_rawDescGZIP(), []int{3}
+	return file_proto_mi8_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NewsList) GetNews() []*News {
@@ -245,29 +342,556 @@ func (x *NewsList) GetNews() []*News {
 	return nil
 }
 
+type GetCityScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCityScoreRequest) Reset() {
+	*x = GetCityScoreRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCityScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCityScoreRequest) ProtoMessage() {}
+
+func (x *GetCityScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCityScoreRequest.ProtoReflect.Descriptor instead.
+func (*GetCityScoreRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCityScoreRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type GetTopCitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopCitiesRequest) Reset() {
+	*x = GetTopCitiesRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopCitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopCitiesRequest) ProtoMessage() {}
+
+func (x *GetTopCitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopCitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetTopCitiesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTopCitiesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CityScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	QualityOfLife int32                  `protobuf:"varint,3,opt,name=quality_of_life,json=qualityOfLife,proto3" json:"quality_of_life,omitempty"`
+	Safety        int32                  `protobuf:"varint,4,opt,name=safety,proto3" json:"safety,omitempty"`
+	Economy       int32                  `protobuf:"varint,5,opt,name=economy,proto3" json:"economy,omitempty"`
+	Culture       int32                  `protobuf:"varint,6,opt,name=culture,proto3" json:"culture,omitempty"`
+	LastUpdated   string                 `protobuf:"bytes,7,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CityScore) Reset() {
+	*x = CityScore{}
+	mi := &file_proto_mi8_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CityScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityScore) ProtoMessage() {}
+
+func (x *CityScore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityScore.ProtoReflect.Descriptor instead.
+func (*CityScore) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CityScore) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CityScore) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CityScore) GetQualityOfLife() int32 {
+	if x != nil {
+		return x.QualityOfLife
+	}
+	return 0
+}
+
+func (x *CityScore) GetSafety() int32 {
+	if x != nil {
+		return x.Safety
+	}
+	return 0
+}
+
+func (x *CityScore) GetEconomy() int32 {
+	if x != nil {
+		return x.Economy
+	}
+	return 0
+}
+
+func (x *CityScore) GetCulture() int32 {
+	if x != nil {
+		return x.Culture
+	}
+	return 0
+}
+
+func (x *CityScore) GetLastUpdated() string {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return ""
+}
+
+type CityScoreList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scores        []*CityScore           `protobuf:"bytes,1,rep,name=scores,proto3" json:"scores,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CityScoreList) Reset() {
+	*x = CityScoreList{}
+	mi := &file_proto_mi8_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CityScoreList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityScoreList) ProtoMessage() {}
+
+func (x *CityScoreList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityScoreList.ProtoReflect.Descriptor instead.
+func (*CityScoreList) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CityScoreList) GetScores() []*CityScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+type SubscribeNewsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filters, empty means any. A news matches if it has at least one of the tags.
+	City    string   `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Country string   `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Tags    []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Id of the last event received, to resume a subscription without missing news
+	ResumeFrom    string `protobuf:"bytes,4,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeNewsRequest) Reset() {
+	*x = SubscribeNewsRequest{}
+	mi := &file_proto_mi8_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeNewsRequest) ProtoMessage() {}
+
+func (x *SubscribeNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeNewsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeNewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscribeNewsRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *SubscribeNewsRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SubscribeNewsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SubscribeNewsRequest) GetResumeFrom() string {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return ""
+}
+
+type NewsEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	News          *News                  `protobuf:"bytes,2,opt,name=news,proto3" json:"news,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewsEvent) Reset() {
+	*x = NewsEvent{}
+	mi := &file_proto_mi8_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewsEvent) ProtoMessage() {}
+
+func (x *NewsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewsEvent.ProtoReflect.Descriptor instead.
+func (*NewsEvent) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NewsEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NewsEvent) GetNews() *News {
+	if x != nil {
+		return x.News
+	}
+	return nil
+}
+
+type IngestNewsSummary struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Accepted   int32                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected   int32                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Duplicates int32                  `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	// Rejected and duplicate news
+	Errors        []*IngestNewsError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestNewsSummary) Reset() {
+	*x = IngestNewsSummary{}
+	mi := &file_proto_mi8_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestNewsSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestNewsSummary) ProtoMessage() {}
+
+func (x *IngestNewsSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestNewsSummary.ProtoReflect.Descriptor instead.
+func (*IngestNewsSummary) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IngestNewsSummary) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *IngestNewsSummary) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *IngestNewsSummary) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *IngestNewsSummary) GetErrors() []*IngestNewsError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type IngestNewsError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the news in the stream, from 0
+	Index         int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Duplicate     bool   `protobuf:"varint,3,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestNewsError) Reset() {
+	*x = IngestNewsError{}
+	mi := &file_proto_mi8_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestNewsError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestNewsError) ProtoMessage() {}
+
+func (x *IngestNewsError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mi8_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestNewsError.ProtoReflect.Descriptor instead.
+func (*IngestNewsError) Descriptor() ([]byte, []int) {
+	return file_proto_mi8_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *IngestNewsError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IngestNewsError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *IngestNewsError) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
 var File_proto_mi8_proto protoreflect.FileDescriptor
 
 const file_proto_mi8_proto_rawDesc = "" +
 	"\n" +
-	"\x0fproto/mi8.proto\x12\x03mi8\"\x88\x01\n" +
+	"\x0fproto/mi8.proto\x12\x03mi8\x1a\x1bgoogle/protobuf/empty.proto\"\x98\x01\n" +
 	"\x04News\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x12\x12\n" +
 	"\x04date\x18\x03 \x01(\tR\x04date\x12\x12\n" +
 	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x12\n" +
 	"\x04city\x18\x05 \x01(\tR\x04city\x12\x18\n" +
-	"\acountry\x18\x06 \x01(\tR\acountry\",\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\x12\x0e\n" +
+	"\x02id\x18\a \x01(\tR\x02id\" \n" +
+	"\x0eGetNewsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"#\n" +
+	"\x11DeleteNewsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
 	"\x14GetLatestNewsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\"F\n" +
 	"\x1aGetLatestNewsInCityRequest\x12\x12\n" +
 	"\x04city\x18\x01 \x01(\tR\x04city\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\")\n" +
 	"\bNewsList\x12\x1d\n" +
-	"\x04news\x18\x01 \x03(\v2\t.mi8.NewsR\x04news2\x92\x01\n" +
+	"\x04news\x18\x01 \x03(\v2\t.mi8.NewsR\x04news\")\n" +
+	"\x13GetCityScoreRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\"+\n" +
+	"\x13GetTopCitiesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"\xd0\x01\n" +
+	"\tCityScore\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12&\n" +
+	"\x0fquality_of_life\x18\x03 \x01(\x05R\rqualityOfLife\x12\x16\n" +
+	"\x06safety\x18\x04 \x01(\x05R\x06safety\x12\x18\n" +
+	"\aeconomy\x18\x05 \x01(\x05R\aeconomy\x12\x18\n" +
+	"\aculture\x18\x06 \x01(\x05R\aculture\x12!\n" +
+	"\flast_updated\x18\a \x01(\tR\vlastUpdated\"7\n" +
+	"\rCityScoreList\x12&\n" +
+	"\x06scores\x18\x01 \x03(\v2\x0e.mi8.CityScoreR\x06scores\"y\n" +
+	"\x14SubscribeNewsRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vresume_from\x18\x04 \x01(\tR\n" +
+	"resumeFrom\":\n" +
+	"\tNewsEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\x04news\x18\x02 \x01(\v2\t.mi8.NewsR\x04news\"\x99\x01\n" +
+	"\x11IngestNewsSummary\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x05R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x02 \x01(\x05R\brejected\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x05R\n" +
+	"duplicates\x12,\n" +
+	"\x06errors\x18\x04 \x03(\v2\x14.mi8.IngestNewsErrorR\x06errors\"[\n" +
+	"\x0fIngestNewsError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1c\n" +
+	"\tduplicate\x18\x03 \x01(\bR\tduplicate2\xbc\x04\n" +
 	"\n" +
 	"MI8Service\x12;\n" +
 	"\rGetLatestNews\x12\x19.mi8.GetLatestNewsRequest\x1a\r.mi8.NewsList\"\x00\x12G\n" +
-	"\x13GetLatestNewsInCity\x12\x1f.mi8.GetLatestNewsInCityRequest\x1a\r.mi8.NewsList\"\x00B\x10Z\x0epolytech/protob\x06proto3"
+	"\x13GetLatestNewsInCity\x12\x1f.mi8.GetLatestNewsInCityRequest\x1a\r.mi8.NewsList\"\x00\x12$\n" +
+	"\n" +
+	"CreateNews\x12\t.mi8.News\x1a\t.mi8.News\"\x00\x12+\n" +
+	"\aGetNews\x12\x13.mi8.GetNewsRequest\x1a\t.mi8.News\"\x00\x12$\n" +
+	"\n" +
+	"UpdateNews\x12\t.mi8.News\x1a\t.mi8.News\"\x00\x12>\n" +
+	"\n" +
+	"DeleteNews\x12\x16.mi8.DeleteNewsRequest\x1a\x16.google.protobuf.Empty\"\x00\x12:\n" +
+	"\fGetCityScore\x12\x18.mi8.GetCityScoreRequest\x1a\x0e.mi8.CityScore\"\x00\x12>\n" +
+	"\fGetTopCities\x12\x18.mi8.GetTopCitiesRequest\x1a\x12.mi8.CityScoreList\"\x00\x12>\n" +
+	"\rSubscribeNews\x12\x19.mi8.SubscribeNewsRequest\x1a\x0e.mi8.NewsEvent\"\x000\x01\x123\n" +
+	"\n" +
+	"IngestNews\x12\t.mi8.News\x1a\x16.mi8.IngestNewsSummary\"\x00(\x01B\x10Z\x0epolytech/protob\x06proto3"
 
 var (
 	file_proto_mi8_proto_rawDescOnce sync.Once
@@ -281,24 +905,54 @@ func file_proto_mi8_proto_rawDescGZIP() []byte {
 	return file_proto_mi8_proto_rawDescData
 }
 
-var file_proto_mi8_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_mi8_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_mi8_proto_goTypes = []any{
 	(*News)(nil),                       // 0: mi8.News
-	(*GetLatestNewsRequest)(nil),       // 1: mi8.GetLatestNewsRequest
-	(*GetLatestNewsInCityRequest)(nil), // 2: mi8.GetLatestNewsInCityRequest
-	(*NewsList)(nil),                   // 3: mi8.NewsList
+	(*GetNewsRequest)(nil),             // 1: mi8.GetNewsRequest
+	(*DeleteNewsRequest)(nil),          // 2: mi8.DeleteNewsRequest
+	(*GetLatestNewsRequest)(nil),       // 3: mi8.GetLatestNewsRequest
+	(*GetLatestNewsInCityRequest)(nil), // 4: mi8.GetLatestNewsInCityRequest
+	(*NewsList)(nil),                   // 5: mi8.NewsList
+	(*GetCityScoreRequest)(nil),        // 6: mi8.GetCityScoreRequest
+	(*GetTopCitiesRequest)(nil),        // 7: mi8.GetTopCitiesRequest
+	(*CityScore)(nil),                  // 8: mi8.CityScore
+	(*CityScoreList)(nil),              // 9: mi8.CityScoreList
+	(*SubscribeNewsRequest)(nil),       // 10: mi8.SubscribeNewsRequest
+	(*NewsEvent)(nil),                  // 11: mi8.NewsEvent
+	(*IngestNewsSummary)(nil),          // 12: mi8.IngestNewsSummary
+	(*IngestNewsError)(nil),            // 13: mi8.IngestNewsError
+	(*emptypb.Empty)(nil),              // 14: google.protobuf.Empty
 }
 var file_proto_mi8_proto_depIdxs = []int32{
-	0, // 0: mi8.NewsList.news:type_name -> mi8.News
-	1, // 1: mi8.MI8Service.GetLatestNews:input_type -> mi8.GetLatestNewsRequest
-	2, // 2: mi8.MI8Service.GetLatestNewsInCity:input_type -> mi8.GetLatestNewsInCityRequest
-	3, // 3: mi8.MI8Service.GetLatestNews:output_type -> mi8.NewsList
-	3, // 4: mi8.MI8Service.GetLatestNewsInCity:output_type -> mi8.NewsList
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: mi8.NewsList.news:type_name -> mi8.News
+	8,  // 1: mi8.CityScoreList.scores:type_name -> mi8.CityScore
+	0,  // 2: mi8.NewsEvent.news:type_name -> mi8.News
+	13, // 3: mi8.IngestNewsSummary.errors:type_name -> mi8.IngestNewsError
+	3,  // 4: mi8.MI8Service.GetLatestNews:input_type -> mi8.GetLatestNewsRequest
+	4,  // 5: mi8.MI8Service.GetLatestNewsInCity:input_type -> mi8.GetLatestNewsInCityRequest
+	0,  // 6: mi8.MI8Service.CreateNews:input_type -> mi8.News
+	1,  // 7: mi8.MI8Service.GetNews:input_type -> mi8.GetNewsRequest
+	0,  // 8: mi8.MI8Service.UpdateNews:input_type -> mi8.News
+	2,  // 9: mi8.MI8Service.DeleteNews:input_type -> mi8.DeleteNewsRequest
+	6,  // 10: mi8.MI8Service.GetCityScore:input_type -> mi8.GetCityScoreRequest
+	7,  // 11: mi8.MI8Service.GetTopCities:input_type -> mi8.GetTopCitiesRequest
+	10, // 12: mi8.MI8Service.SubscribeNews:input_type -> mi8.SubscribeNewsRequest
+	0,  // 13: mi8.MI8Service.IngestNews:input_type -> mi8.News
+	5,  // 14: mi8.MI8Service.GetLatestNews:output_type -> mi8.NewsList
+	5,  // 15: mi8.MI8Service.GetLatestNewsInCity:output_type -> mi8.NewsList
+	0,  // 16: mi8.MI8Service.CreateNews:output_type -> mi8.News
+	0,  // 17: mi8.MI8Service.GetNews:output_type -> mi8.News
+	0,  // 18: mi8.MI8Service.UpdateNews:output_type -> mi8.News
+	14, // 19: mi8.MI8Service.DeleteNews:output_type -> google.protobuf.Empty
+	8,  // 20: mi8.MI8Service.GetCityScore:output_type -> mi8.CityScore
+	9,  // 21: mi8.MI8Service.GetTopCities:output_type -> mi8.CityScoreList
+	11, // 22: mi8.MI8Service.SubscribeNews:output_type -> mi8.NewsEvent
+	12, // 23: mi8.MI8Service.IngestNews:output_type -> mi8.IngestNewsSummary
+	14, // [14:24] is the sub-list for method output_type
+	4,  // [4:14] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_mi8_proto_init() }
@@ -312,7 +966,7 @@ func file_proto_mi8_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_mi8_proto_rawDesc), len(file_proto_mi8_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -21,6 +22,14 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	MI8Service_GetLatestNews_FullMethodName       = "/mi8.MI8Service/GetLatestNews"
 	MI8Service_GetLatestNewsInCity_FullMethodName = "/mi8.MI8Service/GetLatestNewsInCity"
+	MI8Service_CreateNews_FullMethodName          = "/mi8.MI8Service/CreateNews"
+	MI8Service_GetNews_FullMethodName             = "/mi8.MI8Service/GetNews"
+	MI8Service_UpdateNews_FullMethodName          = "/mi8.MI8Service/UpdateNews"
+	MI8Service_DeleteNews_FullMethodName          = "/mi8.MI8Service/DeleteNews"
+	MI8Service_GetCityScore_FullMethodName        = "/mi8.MI8Service/GetCityScore"
+	MI8Service_GetTopCities_FullMethodName        = "/mi8.MI8Service/GetTopCities"
+	MI8Service_SubscribeNews_FullMethodName       = "/mi8.MI8Service/SubscribeNews"
+	MI8Service_IngestNews_FullMethodName          = "/mi8.MI8Service/IngestNews"
 )
 
 // MI8ServiceClient is the client API for MI8Service service.
@@ -33,6 +42,22 @@ type MI8ServiceClient interface {
 	GetLatestNews(ctx context.Context, in *GetLatestNewsRequest, opts ...grpc.CallOption) (*NewsList, error)
 	// Get latest news by city
 	GetLatestNewsInCity(ctx context.Context, in *GetLatestNewsInCityRequest, opts ...grpc.CallOption) (*NewsList, error)
+	// Create news, the created news has its id set
+	CreateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*News, error)
+	// Get news by id
+	GetNews(ctx context.Context, in *GetNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Update news, its impact on city scores is updated too
+	UpdateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*News, error)
+	// Delete news, its impact on city scores is reversed
+	DeleteNews(ctx context.Context, in *DeleteNewsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Get city scores
+	GetCityScore(ctx context.Context, in *GetCityScoreRequest, opts ...grpc.CallOption) (*CityScore, error)
+	// Get top cities
+	GetTopCities(ctx context.Context, in *GetTopCitiesRequest, opts ...grpc.CallOption) (*CityScoreList, error)
+	// Stream news as they are created, optionally filtered
+	SubscribeNews(ctx context.Context, in *SubscribeNewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewsEvent], error)
+	// Create news in bulk, applied in batches
+	IngestNews(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[News, IngestNewsSummary], error)
 }
 
 type mI8ServiceClient struct {
@@ -63,6 +88,98 @@ func (c *mI8ServiceClient) GetLatestNewsInCity(ctx context.Context, in *GetLates
 	return out, nil
 }
 
+func (c *mI8ServiceClient) CreateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, MI8Service_CreateNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) GetNews(ctx context.Context, in *GetNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, MI8Service_GetNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) UpdateNews(ctx context.Context, in *News, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, MI8Service_UpdateNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) DeleteNews(ctx context.Context, in *DeleteNewsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MI8Service_DeleteNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) GetCityScore(ctx context.Context, in *GetCityScoreRequest, opts ...grpc.CallOption) (*CityScore, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CityScore)
+	err := c.cc.Invoke(ctx, MI8Service_GetCityScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) GetTopCities(ctx context.Context, in *GetTopCitiesRequest, opts ...grpc.CallOption) (*CityScoreList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CityScoreList)
+	err := c.cc.Invoke(ctx, MI8Service_GetTopCities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mI8ServiceClient) SubscribeNews(ctx context.Context, in *SubscribeNewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewsEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MI8Service_ServiceDesc.Streams[0], MI8Service_SubscribeNews_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeNewsRequest, NewsEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MI8Service_SubscribeNewsClient = grpc.ServerStreamingClient[NewsEvent]
+
+func (c *mI8ServiceClient) IngestNews(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[News, IngestNewsSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MI8Service_ServiceDesc.Streams[1], MI8Service_IngestNews_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[News, IngestNewsSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MI8Service_IngestNewsClient = grpc.ClientStreamingClient[News, IngestNewsSummary]
+
 // MI8ServiceServer is the server API for MI8Service service.
 // All implementations must embed UnimplementedMI8ServiceServer
 // for forward compatibility.
@@ -73,6 +190,22 @@ type MI8ServiceServer interface {
 	GetLatestNews(context.Context, *GetLatestNewsRequest) (*NewsList, error)
 	// Get latest news by city
 	GetLatestNewsInCity(context.Context, *GetLatestNewsInCityRequest) (*NewsList, error)
+	// Create news, the created news has its id set
+	CreateNews(context.Context, *News) (*News, error)
+	// Get news by id
+	GetNews(context.Context, *GetNewsRequest) (*News, error)
+	// Update news, its impact on city scores is updated too
+	UpdateNews(context.Context, *News) (*News, error)
+	// Delete news, its impact on city scores is reversed
+	DeleteNews(context.Context, *DeleteNewsRequest) (*emptypb.Empty, error)
+	// Get city scores
+	GetCityScore(context.Context, *GetCityScoreRequest) (*CityScore, error)
+	// Get top cities
+	GetTopCities(context.Context, *GetTopCitiesRequest) (*CityScoreList, error)
+	// Stream news as they are created, optionally filtered
+	SubscribeNews(*SubscribeNewsRequest, grpc.ServerStreamingServer[NewsEvent]) error
+	// Create news in bulk, applied in batches
+	IngestNews(grpc.ClientStreamingServer[News, IngestNewsSummary]) error
 	mustEmbedUnimplementedMI8ServiceServer()
 }
 
@@ -89,6 +222,30 @@ func (UnimplementedMI8ServiceServer) GetLatestNews(context.Context, *GetLatestNe
 func (UnimplementedMI8ServiceServer) GetLatestNewsInCity(context.Context, *GetLatestNewsInCityRequest) (*NewsList, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetLatestNewsInCity not implemented")
 }
+func (UnimplementedMI8ServiceServer) CreateNews(context.Context, *News) (*News, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateNews not implemented")
+}
+func (UnimplementedMI8ServiceServer) GetNews(context.Context, *GetNewsRequest) (*News, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNews not implemented")
+}
+func (UnimplementedMI8ServiceServer) UpdateNews(context.Context, *News) (*News, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateNews not implemented")
+}
+func (UnimplementedMI8ServiceServer) DeleteNews(context.Context, *DeleteNewsRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteNews not implemented")
+}
+func (UnimplementedMI8ServiceServer) GetCityScore(context.Context, *GetCityScoreRequest) (*CityScore, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCityScore not implemented")
+}
+func (UnimplementedMI8ServiceServer) GetTopCities(context.Context, *GetTopCitiesRequest) (*CityScoreList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTopCities not implemented")
+}
+func (UnimplementedMI8ServiceServer) SubscribeNews(*SubscribeNewsRequest, grpc.ServerStreamingServer[NewsEvent]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeNews not implemented")
+}
+func (UnimplementedMI8ServiceServer) IngestNews(grpc.ClientStreamingServer[News, IngestNewsSummary]) error {
+	return status.Error(codes.Unimplemented, "method IngestNews not implemented")
+}
 func (UnimplementedMI8ServiceServer) mustEmbedUnimplementedMI8ServiceServer() {}
 func (UnimplementedMI8ServiceServer) testEmbeddedByValue()                    {}
 
@@ -146,6 +303,132 @@ func _MI8Service_GetLatestNewsInCity_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MI8Service_CreateNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(News)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).CreateNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_CreateNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).CreateNews(ctx, req.(*News))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_GetNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).GetNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_GetNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).GetNews(ctx, req.(*GetNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_UpdateNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(News)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).UpdateNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_UpdateNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).UpdateNews(ctx, req.(*News))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_DeleteNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).DeleteNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_DeleteNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).DeleteNews(ctx, req.(*DeleteNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_GetCityScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCityScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).GetCityScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_GetCityScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).GetCityScore(ctx, req.(*GetCityScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_GetTopCities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopCitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MI8ServiceServer).GetTopCities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MI8Service_GetTopCities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MI8ServiceServer).GetTopCities(ctx, req.(*GetTopCitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MI8Service_SubscribeNews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeNewsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MI8ServiceServer).SubscribeNews(m, &grpc.GenericServerStream[SubscribeNewsRequest, NewsEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MI8Service_SubscribeNewsServer = grpc.ServerStreamingServer[NewsEvent]
+
+func _MI8Service_IngestNews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MI8ServiceServer).IngestNews(&grpc.GenericServerStream[News, IngestNewsSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MI8Service_IngestNewsServer = grpc.ClientStreamingServer[News, IngestNewsSummary]
+
 // MI8Service_ServiceDesc is the grpc.ServiceDesc for MI8Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,7 +444,42 @@ var MI8Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLatestNewsInCity",
 			Handler:    _MI8Service_GetLatestNewsInCity_Handler,
 		},
+		{
+			MethodName: "CreateNews",
+			Handler:    _MI8Service_CreateNews_Handler,
+		},
+		{
+			MethodName: "GetNews",
+			Handler:    _MI8Service_GetNews_Handler,
+		},
+		{
+			MethodName: "UpdateNews",
+			Handler:    _MI8Service_UpdateNews_Handler,
+		},
+		{
+			MethodName: "DeleteNews",
+			Handler:    _MI8Service_DeleteNews_Handler,
+		},
+		{
+			MethodName: "GetCityScore",
+			Handler:    _MI8Service_GetCityScore_Handler,
+		},
+		{
+			MethodName: "GetTopCities",
+			Handler:    _MI8Service_GetTopCities_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeNews",
+			Handler:       _MI8Service_SubscribeNews_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IngestNews",
+			Handler:       _MI8Service_IngestNews_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/mi8.proto",
 }
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+
+	"polytech/client"
+)
+
+// Criteria an offer is ranked on, and the query parameter setting their weight
+var recommendationCriteria = []string{"salary", "safety", "economy", "culture", "qualityOfLife"}
+
+type Recommendation struct {
+	Offer     ErasmumuOffer      `json:"offer"`
+	CityScore *client.CityScore  `json:"cityScore"` // null when MI8 has no score for the city
+	Criteria  map[string]float64 `json:"criteria"`  // each criterion scaled to 0..1 among the recommended offers
+	Score     float64            `json:"score"`
+}
+
+// GET /student/{id}/recommendations?salary=1&safety=1&economy=1&culture=1&qualityOfLife=1&limit=10
+//
+// Available offers in the student's domain, ranked by the weighted sum of their criteria.
+// Weights default to 1; 0 ignores a criterion.
+func (h *mi8Handlers) getRecommendations(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	weights := map[string]float64{}
+	for _, c := range recommendationCriteria {
+		weights[c] = 1
+		if v := r.URL.Query().Get(c); v != "" {
+			weight, err := strconv.ParseFloat(v, 64)
+			if err != nil || weight < 0 {
+				http.Error(w, "Invalid weight for "+c, http.StatusBadRequest)
+				return
+			}
+			weights[c] = weight
+		}
+	}
+	limit := 10
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx := context.Background()
+	student, err := loadStudent(ctx, id)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	list, err := fetchOffers(ctx, student.Domain)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	// Offers the student already applied to aren't recommended again
+	rows, err := db.Query(ctx, "SELECT offer_id FROM registrations WHERE student_id=$1 AND status = ANY($2)", id, activeStatuses)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	applied, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	skip := map[string]bool{}
+	for _, offerID := range applied {
+		skip[offerID] = true
+	}
+
+	recommendations := []Recommendation{}
+	for _, offer := range list {
+		if status, _ := checkEligibility(student, offer, exactDomainMatch); status == statusApproved && !skip[offer.ID] {
+			recommendations = append(recommendations, Recommendation{Offer: offer})
+		}
+	}
+
+	scores := h.cityScores(recommendations)
+	for i := range recommendations {
+		rec := &recommendations[i]
+		rec.CityScore = scores[rec.Offer.City]
+		rec.Criteria = map[string]float64{"salary": rec.Offer.Salary}
+		if rec.CityScore != nil {
+			rec.Criteria["safety"] = float64(rec.CityScore.Safety)
+			rec.Criteria["economy"] = float64(rec.CityScore.Economy)
+			rec.Criteria["culture"] = float64(rec.CityScore.Culture)
+			rec.Criteria["qualityOfLife"] = float64(rec.CityScore.QualityOfLife)
+		}
+	}
+	rankRecommendations(recommendations, weights)
+
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	jsonResponse(w, http.StatusOK, recommendations)
+}
+
+// cityScores asks MI8 for the score of every city of the recommendations, in parallel.
+// MI8 being down or not knowing a city only leaves the score out.
+func (h *mi8Handlers) cityScores(recommendations []Recommendation) map[string]*client.CityScore {
+	scores := map[string]*client.CityScore{}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// Cities are listed before any request starts, the requests writing to scores
+	var cities []string
+	seen := map[string]bool{}
+	for _, rec := range recommendations {
+		if city := rec.Offer.City; city != "" && !seen[city] {
+			seen[city] = true
+			cities = append(cities, city)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, city := range cities {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			score, err := h.mi8.GetCityScore(ctx, city)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			scores[city] = score
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return scores
+}
+
+// rankRecommendations scales every criterion to 0..1 (min-max among the offers, as MI8 scores
+// and salaries have no common unit), then sorts by the weighted sum, best first
+func rankRecommendations(recommendations []Recommendation, weights map[string]float64) {
+	for _, c := range recommendationCriteria {
+		min, max := 0.0, 0.0
+		first := true
+		for _, rec := range recommendations {
+			v, ok := rec.Criteria[c]
+			if !ok {
+				continue
+			}
+			if first || v < min {
+				min = v
+			}
+			if first || v > max {
+				max = v
+			}
+			first = false
+		}
+		for _, rec := range recommendations {
+			v, ok := rec.Criteria[c]
+			if !ok {
+				continue // unknown criteria count as 0
+			}
+			scaled := 1.0
+			if max > min {
+				scaled = (v - min) / (max - min)
+			}
+			rec.Criteria[c] = scaled
+		}
+	}
+
+	for i := range recommendations {
+		rec := &recommendations[i]
+		for _, c := range recommendationCriteria {
+			rec.Score += weights[c] * rec.Criteria[c]
+		}
+	}
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+}
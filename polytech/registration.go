@@ -3,13 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
 )
 
 type Registration struct {
@@ -20,10 +21,39 @@ type Registration struct {
 	Message   string `json:"message"`
 }
 
+// Registration statuses
+const (
+	statusPending  = "pending" // seat reservation in progress
+	statusApproved = "approved"
+	statusRejected = "rejected"
+	statusFailed   = "failed" // approval couldn't be completed, see message
+
+	// Set when the offer changed after approval (see revalidation.go)
+	statusNeedsAttention = "needs_attention"
+	statusCancelled      = "cancelled"
+
+	// Offers in selection mode (see selection.go)
+	statusApplied    = "applied"    // waiting for the selection deadline
+	statusWaitlisted = "waitlisted" // not selected, may get a seat later
+
+	// Agreement signed and documents complete (see documents.go)
+	statusConfirmed = "confirmed"
+)
+
+// Statuses of registrations holding, or about to hold, a seat
+var activeStatuses = []string{statusPending, statusApproved, statusNeedsAttention, statusApplied, statusWaitlisted, statusConfirmed}
+
+// holdsSeat tells whether a registration in this status has a seat reserved in Erasmumu
+func holdsSeat(status string) bool {
+	return status == statusApproved || status == statusNeedsAttention || status == statusConfirmed
+}
+
 type ErasmumuOffer struct {
 	ID        string  `json:"id"`
 	Title     string  `json:"title"`
+	City      string  `json:"city"`
 	Domain    string  `json:"domain"`
+	Salary    float64 `json:"salary"`
 	Available bool    `json:"available"`
 }
 
@@ -39,70 +69,180 @@ func registerInternship(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 1. Get Student
-	var student Student
-	err := db.QueryRow(context.Background(),
-		"SELECT id, firstname, name, domain FROM students WHERE id=$1", input.StudentID).Scan(&student.ID, &student.Firstname, &student.Name, &student.Domain)
+	student, err := loadStudent(context.Background(), input.StudentID)
 	if err != nil {
 		http.Error(w, "Student not found", http.StatusNotFound)
 		return
 	}
 
-	// 2. Get Offer from Erasmumu
-	// Use environment variable for service URL if available, else default to docker service name or localhost depending on context
-	erasmumuURL := "http://erasmumu:8080" 
-    if os.Getenv("ERASMUMU_URL") != "" {
-        erasmumuURL = os.Getenv("ERASMUMU_URL")
-    }
-
-	resp, err := http.Get(fmt.Sprintf("%s/offer/%s", erasmumuURL, input.OfferID))
+	// 2. Get Offer from Erasmumu (through the offer cache)
+	offer, err := offers.Get(r.Context(), input.OfferID)
+	if errors.Is(err, errOfferNotFound) {
+		http.Error(w, "Offer not found", http.StatusNotFound)
+		return
+	}
 	if err != nil {
-		http.Error(w, "Failed to contact Erasmumu service: "+err.Error(), http.StatusBadGateway)
+		http.Error(w, err.Error(), http.StatusBadGateway)
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		http.Error(w, "Offer not found", http.StatusNotFound)
+	// Offers in selection mode collect applications until their deadline
+	selection, err := loadSelection(context.Background(), input.OfferID)
+	if err != nil && err != pgx.ErrNoRows {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	inSelection := err == nil
+	minMatch := exactDomainMatch
+	if inSelection {
+		minMatch = relatedDomainMatch
+	}
+
+	// 3. Validation Logic
+	status, message := checkEligibility(student, offer, minMatch)
+	if status == statusApproved && inSelection && selection.ClosedAt == nil {
+		reg, err := applyToSelection(context.Background(), input.StudentID, selection)
+		switch {
+		case err == errSelectionClosed:
+			// Closed meanwhile, or past its deadline: first-come below
+		case err == errAlreadyApplied:
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		default:
+			jsonResponse(w, http.StatusCreated, reg)
+			return
+		}
+	}
+	if status != statusApproved {
+		// 4. Save rejected Registration
+		reg := Registration{
+			StudentID: input.StudentID,
+			OfferID:   input.OfferID,
+			Status:    status,
+			Message:   message,
+		}
+		ctx := context.Background()
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer tx.Rollback(ctx)
+		if err := insertRegistration(ctx, tx, &reg); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := tx.Commit(ctx); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 
-	var offer ErasmumuOffer
-	if err := json.NewDecoder(resp.Body).Decode(&offer); err != nil {
-		http.Error(w, "Failed to parse offer", http.StatusInternalServerError)
+		jsonResponse(w, http.StatusCreated, reg)
 		return
 	}
 
-	// 3. Validation Logic
-	status := "approved"
-	message := "Student successfully registered"
+	// 4. Reserve a seat in Erasmumu, then approve (see saga.go)
+	reg, err := approveRegistration(context.Background(), input.StudentID, input.OfferID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, http.StatusCreated, reg)
+}
 
+// Minimum domainMatch for a student to be eligible. Offers in selection mode rank candidates on
+// how well their domain matches, so they also take related domains.
+const (
+	exactDomainMatch   = 1.0
+	relatedDomainMatch = 0.2
+)
+
+// checkEligibility tells whether the student may be approved for the offer
+func checkEligibility(student Student, offer ErasmumuOffer, minMatch float64) (status string, message string) {
 	if !offer.Available {
-        status = "rejected"
-        message = "Offer is not available"
-    } else if !strings.EqualFold(student.Domain, offer.Domain) {
-		status = "rejected"
-		message = "Offer domain doesn't match" 
+		return statusRejected, "Offer is not available"
+	}
+	if domainMatch(student.Domain, offer.Domain) < minMatch {
+		return statusRejected, "Offer domain doesn't match"
+	}
+	return statusApproved, "Student successfully registered"
+}
+
+// Words ignored when comparing domains
+var domainStopWords = map[string]bool{"and": true, "of": true, "the": true, "et": true, "de": true, "des": true, "du": true, "la": true, "le": true}
+
+// domainMatch rates how close two domains are: 1 for the same domain, the share of words they
+// have in common for related ones ("Computer Science" and "Data Science": 1/3), 0 for unrelated
+func domainMatch(a, b string) float64 {
+	if strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b)) {
+		return 1
+	}
+	words := func(s string) map[string]bool {
+		set := map[string]bool{}
+		for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			if !domainStopWords[w] {
+				set[w] = true
+			}
+		}
+		return set
+	}
+	wa, wb := words(a), words(b)
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
 	}
+	if common == 0 {
+		return 0
+	}
+	return float64(common) / float64(len(wa)+len(wb)-common)
+}
 
-	// 4. Save Registration
-	var regID int
-	err = db.QueryRow(context.Background(),
+// insertRegistration saves reg (setting its ID) and records the registration.created event
+func insertRegistration(ctx context.Context, tx pgx.Tx, reg *Registration) error {
+	err := tx.QueryRow(ctx,
 		"INSERT INTO registrations (student_id, offer_id, status, message) VALUES ($1, $2, $3, $4) RETURNING id",
-		input.StudentID, input.OfferID, status, message).Scan(&regID)
+		reg.StudentID, reg.OfferID, reg.Status, reg.Message).Scan(&reg.ID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return err
+	}
+	return enqueueEvent(ctx, tx, aggregateRegistration, reg.ID, eventRegistrationCreated, reg)
+}
+
+// setRegistrationStatus changes the status of a registration and records the registration.status_changed event
+func setRegistrationStatus(ctx context.Context, tx pgx.Tx, id int, status, message string) error {
+	reg := Registration{ID: id, Status: status, Message: message}
+	err := tx.QueryRow(ctx,
+		"UPDATE registrations SET status=$1, message=$2 WHERE id=$3 RETURNING student_id, offer_id",
+		status, message, id).Scan(&reg.StudentID, &reg.OfferID)
+	if err != nil {
+		return err
 	}
+	return enqueueEvent(ctx, tx, aggregateRegistration, id, eventRegistrationStatusChanged, reg)
+}
 
-	response := Registration{
-		ID:        regID,
-		StudentID: input.StudentID,
-		OfferID:   input.OfferID,
-		Status:    status,
-		Message:   message,
+// cancelRegistration cancels a registration and, if it holds a seat, moves its saga to releasing.
+// The caller releases the seat with freeSeat once the transaction is committed.
+func cancelRegistration(ctx context.Context, tx pgx.Tx, id int, reason string) error {
+	if err := setRegistrationStatus(ctx, tx, id, statusCancelled, reason); err != nil {
+		return err
 	}
-    
-	jsonResponse(w, http.StatusCreated, response)
+	_, err := tx.Exec(ctx,
+		"UPDATE registration_sagas SET state=$1, last_error=$2, updated_at=now() WHERE registration_id=$3 AND state=$4",
+		sagaReleasing, reason, id, sagaCompleted)
+	return err
+}
+
+func loadRegistration(ctx context.Context, id int) (Registration, error) {
+	var reg Registration
+	err := db.QueryRow(ctx,
+		"SELECT id, student_id, offer_id, status, message FROM registrations WHERE id=$1", id).Scan(&reg.ID, &reg.StudentID, &reg.OfferID, &reg.Status, &reg.Message)
+	return reg, err
 }
 
 // GET /internship/:id
@@ -114,9 +254,7 @@ func getRegistration(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var reg Registration
-	err = db.QueryRow(context.Background(),
-		"SELECT id, student_id, offer_id, status, message FROM registrations WHERE id=$1", id).Scan(&reg.ID, &reg.StudentID, &reg.OfferID, &reg.Status, &reg.Message)
+	reg, err := loadRegistration(context.Background(), id)
 	if err != nil {
 		http.Error(w, "Registration not found", http.StatusNotFound)
 		return
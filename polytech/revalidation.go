@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Re-validation of registrations after an offer changed.
+//
+// Erasmumu notifies us of offer changes (see handleOfferEvent) and we also poll the offers of
+// active registrations, in case a notification was lost. Each registration holding a seat is
+// checked again against the current offer:
+//   - offer deleted or no longer available: cancelled, and its seat is released
+//   - a confirmed registration otherwise stays confirmed, the student having accepted the offer
+//   - offer domain no longer matches the student (or isn't related anymore, for offers in
+//     selection mode): needs_attention (the seat is kept)
+//   - a needs_attention registration matching again goes back to approved
+//
+// The reason is stored as the registration message and the student is notified.
+
+// revalidateOffer re-runs eligibility for every active registration of the offer.
+// The offer comes from the cache, callers reacting to a change notification invalidate it first.
+func revalidateOffer(ctx context.Context, offerID string) error {
+	offer, err := offers.Get(ctx, offerID)
+	var current *ErasmumuOffer
+	switch {
+	case errors.Is(err, errOfferNotFound):
+		// Erasmumu doesn't expose unavailable offers, gone and unavailable look the same
+	case err != nil:
+		return err
+	default:
+		current = &offer
+	}
+
+	rows, err := db.Query(ctx,
+		"SELECT id FROM registrations WHERE offer_id=$1 AND status IN ($2, $3, $4) ORDER BY id",
+		offerID, statusApproved, statusNeedsAttention, statusConfirmed)
+	if err != nil {
+		return err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return err
+	}
+	// On error the offer is checked again by the next revalidation
+	minMatch, err := requiredDomainMatch(ctx, offerID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := revalidateRegistration(ctx, id, offerID, current, minMatch); err != nil {
+			return fmt.Errorf("registration %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// revalidateRegistration applies the current offer (nil when gone) to one registration, whose
+// domain must match the offer's by minMatch
+func revalidateRegistration(ctx context.Context, regID int, offerID string, offer *ErasmumuOffer, minMatch float64) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var status, domain string
+	var studentID int
+	err = tx.QueryRow(ctx,
+		"SELECT r.status, r.student_id, s.domain FROM registrations r JOIN students s ON s.id = r.student_id WHERE r.id=$1 FOR UPDATE OF r",
+		regID).Scan(&status, &studentID, &domain)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !holdsSeat(status) {
+		// Changed since we listed it
+		return nil
+	}
+
+	newStatus, reason := statusApproved, "Offer changed, the registration is still valid"
+	switch {
+	case offer == nil || !offer.Available:
+		newStatus, reason = statusCancelled, "Offer is no longer available"
+	case status == statusConfirmed:
+		return nil
+	case domainMatch(domain, offer.Domain) < minMatch:
+		newStatus = statusNeedsAttention
+		reason = fmt.Sprintf("Offer domain changed to %q and no longer matches the student's domain %q", offer.Domain, domain)
+	}
+	if newStatus == status {
+		return nil
+	}
+
+	if newStatus == statusCancelled {
+		err = cancelRegistration(ctx, tx, regID, reason)
+	} else {
+		err = setRegistrationStatus(ctx, tx, regID, newStatus, reason)
+	}
+	if err != nil {
+		return err
+	}
+	if err := notifyStudent(ctx, tx, studentID, regID, reason); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if newStatus == statusCancelled {
+		freeSeat(ctx, regID, offerID)
+	}
+	return nil
+}
+
+// runOfferRevalidation periodically re-checks the offers of all active registrations
+func runOfferRevalidation(interval time.Duration) {
+	for range time.Tick(interval) {
+		ctx := context.Background()
+		rows, err := db.Query(ctx,
+			"SELECT DISTINCT offer_id FROM registrations WHERE status IN ($1, $2, $3)",
+			statusApproved, statusNeedsAttention, statusConfirmed)
+		if err != nil {
+			fmt.Printf("Offer revalidation: %v\n", err)
+			continue
+		}
+		offerIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			fmt.Printf("Offer revalidation: %v\n", err)
+			continue
+		}
+
+		for _, id := range offerIDs {
+			if err := revalidateOffer(ctx, id); err != nil {
+				fmt.Printf("Offer revalidation of %s: %v\n", id, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Seat reservation saga.
+//
+// Approving a registration spans two services: a seat is reserved in Erasmumu, then the
+// registration is approved here. The saga row (registration_sagas) records how far we got so a
+// crash between the two steps is resumed at restart instead of leaking a seat or leaving the
+// registration pending forever.
+//
+//	reserving --seat held, approval saved--> completed
+//	reserving --no seat--------------------> compensated (registration waitlisted)
+//	reserving --offer gone-----------------> compensated (registration rejected)
+//	reserving --failure, seat unknown------> releasing (registration failed) --seat released--> compensated
+const (
+	sagaReserving   = "reserving"
+	sagaCompleted   = "completed"
+	sagaReleasing   = "releasing"
+	sagaCompensated = "compensated"
+)
+
+// Key identifying the seat held for a registration in Erasmumu
+func seatKey(registrationID int) string {
+	return fmt.Sprintf("registration-%d", registrationID)
+}
+
+// approveRegistration creates a pending registration with its saga and runs the reservation.
+// The returned registration carries the outcome (approved, rejected or failed).
+func approveRegistration(ctx context.Context, studentID int, offerID string) (Registration, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return Registration{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	reg := Registration{StudentID: studentID, OfferID: offerID, Status: statusPending, Message: "Reserving a seat"}
+	if err := insertRegistration(ctx, tx, &reg); err != nil {
+		return Registration{}, err
+	}
+	regID := reg.ID
+	if err := startSaga(ctx, tx, regID, offerID); err != nil {
+		return Registration{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return Registration{}, err
+	}
+
+	runReservation(ctx, regID, offerID)
+
+	return loadRegistration(ctx, regID)
+}
+
+// startSaga records the saga of a pending registration, restarting a finished one. The caller
+// runs runReservation once the transaction is committed (or leaves it to recovery).
+func startSaga(ctx context.Context, tx pgx.Tx, regID int, offerID string) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO registration_sagas (registration_id, offer_id, state) VALUES ($1, $2, $3)
+		 ON CONFLICT (registration_id) DO UPDATE SET state=EXCLUDED.state, last_error=NULL, updated_at=now()`,
+		regID, offerID, sagaReserving)
+	return err
+}
+
+// runReservation drives a saga in the reserving state to a terminal (or releasing) state
+func runReservation(ctx context.Context, regID int, offerID string) {
+	err := reserveSeat(ctx, offerID, seatKey(regID))
+	switch {
+	case err == nil:
+		if err := finishSaga(ctx, regID, sagaCompleted, statusApproved, "Student successfully registered", ""); err != nil {
+			// The seat is held but the approval wasn't recorded: give it back
+			compensate(ctx, regID, offerID, "Failed to record approval: "+err.Error())
+		}
+	case errors.Is(err, errNoSeatsLeft):
+		logSagaError(regID, waitlistSaga(ctx, regID, err.Error()))
+	case errors.Is(err, errOfferNotFound):
+		logSagaError(regID, finishSaga(ctx, regID, sagaCompensated, statusRejected, "Offer is not available", err.Error()))
+	default:
+		// We don't know whether Erasmumu reserved the seat, release it to be safe
+		compensate(ctx, regID, offerID, "Seat reservation failed: "+err.Error())
+	}
+}
+
+// compensate marks the registration failed, then releases its seat.
+// If the database can't be updated the saga stays in reserving and is retried on recovery.
+func compensate(ctx context.Context, regID int, offerID string, reason string) {
+	if err := finishSaga(ctx, regID, sagaReleasing, statusFailed, reason, reason); err != nil {
+		logSagaError(regID, err)
+		return
+	}
+	releaseHeldSeat(ctx, regID, offerID)
+}
+
+// releaseHeldSeat completes a saga in the releasing state, telling whether the seat was released
+func releaseHeldSeat(ctx context.Context, regID int, offerID string) bool {
+	if err := releaseSeat(ctx, offerID, seatKey(regID)); err != nil {
+		logSagaError(regID, setSagaState(ctx, regID, sagaReleasing, "Seat release failed: "+err.Error()))
+		return false
+	}
+	logSagaError(regID, setSagaState(ctx, regID, sagaCompensated, ""))
+	return true
+}
+
+// finishSaga moves the saga and its registration together
+func finishSaga(ctx context.Context, regID int, state, status, message, lastError string) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := setRegistrationStatus(ctx, tx, regID, status, message); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx,
+		"UPDATE registration_sagas SET state=$1, last_error=$2, updated_at=now() WHERE registration_id=$3",
+		state, lastError, regID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// waitlistSaga ends a saga that found no seat left, putting its registration on the waitlist
+func waitlistSaga(ctx context.Context, regID int, lastError string) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := waitlistRegistration(ctx, tx, regID, "No seats left for this offer"); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx,
+		"UPDATE registration_sagas SET state=$1, last_error=$2, updated_at=now() WHERE registration_id=$3",
+		sagaCompensated, lastError, regID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func setSagaState(ctx context.Context, regID int, state, lastError string) error {
+	_, err := db.Exec(ctx,
+		"UPDATE registration_sagas SET state=$1, last_error=$2, updated_at=now() WHERE registration_id=$3",
+		state, lastError, regID)
+	return err
+}
+
+func logSagaError(regID int, err error) {
+	if err != nil {
+		fmt.Printf("Saga for registration %d: %v\n", regID, err)
+	}
+}
+
+// recoverSagas resumes sagas left unfinished (crash, Erasmumu down during a release).
+// Only sagas untouched for olderThan are picked so in-flight requests aren't run twice.
+func recoverSagas(ctx context.Context, olderThan time.Duration) {
+	rows, err := db.Query(ctx,
+		"SELECT registration_id, offer_id, state FROM registration_sagas WHERE state IN ($1, $2) AND updated_at <= now() - make_interval(secs => $3) ORDER BY registration_id",
+		sagaReserving, sagaReleasing, olderThan.Seconds())
+	if err != nil {
+		fmt.Printf("Saga recovery: %v\n", err)
+		return
+	}
+
+	type pendingSaga struct {
+		regID   int
+		offerID string
+		state   string
+	}
+	var pending []pendingSaga
+	for rows.Next() {
+		var p pendingSaga
+		if err := rows.Scan(&p.regID, &p.offerID, &p.state); err != nil {
+			rows.Close()
+			fmt.Printf("Saga recovery: %v\n", err)
+			return
+		}
+		pending = append(pending, p)
+	}
+	rows.Close()
+
+	for _, p := range pending {
+		fmt.Printf("Saga recovery: resuming registration %d (%s)\n", p.regID, p.state)
+		if p.state == sagaReserving {
+			runReservation(ctx, p.regID, p.offerID)
+			continue
+		}
+		// The seat of a cancelled registration goes to the waitlist, as freeSeat would have done
+		if releaseHeldSeat(ctx, p.regID, p.offerID) {
+			if reg, err := loadRegistration(ctx, p.regID); err == nil && reg.Status == statusCancelled {
+				promoteNext(ctx, p.offerID)
+			}
+		}
+	}
+}
+
+// runSagaRecovery recovers everything at startup, then periodically retries stuck sagas
+func runSagaRecovery(interval time.Duration) {
+	recoverSagas(context.Background(), 0)
+	for range time.Tick(interval) {
+		recoverSagas(context.Background(), interval)
+	}
+}
@@ -0,0 +1,468 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// Candidate selection for offers with limited seats.
+//
+// By default registrations are first-come, first-served. An offer put in selection mode instead
+// collects registrations (applied) until its deadline. The scheduler then ranks the candidates
+// and gives the seats to the best ones: the top N go through the seat reservation saga, the
+// others are waitlisted (see waitlist.go). Registrations made after the deadline are handled
+// first-come again.
+//
+// Candidates are ranked on the weighted sum of criteria scaled to 0..1:
+//   - domainMatch: how well the student's domain matches the offer's (see domainMatch)
+//   - studyYear: more advanced students first
+//   - applicationTime: earlier applications first
+//   - advisorPriority: set by the student's advisor on the registration
+
+var errAlreadyApplied = errors.New("Student already applied to this offer")
+
+// errSelectionClosed is returned when applying to a selection closed, or past its deadline, since
+// it was loaded: the registration is handled first-come
+var errSelectionClosed = errors.New("Selection is closed")
+
+type SelectionWeights struct {
+	DomainMatch     float64 `json:"domainMatch"`
+	StudyYear       float64 `json:"studyYear"`
+	ApplicationTime float64 `json:"applicationTime"`
+	AdvisorPriority float64 `json:"advisorPriority"`
+}
+
+// Weights of the criteria not given when configuring a selection
+var defaultSelectionWeights = SelectionWeights{DomainMatch: 3, StudyYear: 1, ApplicationTime: 1, AdvisorPriority: 2}
+
+type Selection struct {
+	OfferID  string           `json:"offerId"`
+	Deadline time.Time        `json:"deadline"`
+	Seats    int              `json:"seats"` // registrations approved when the selection closes
+	Weights  SelectionWeights `json:"weights"`
+	ClosedAt *time.Time       `json:"closedAt,omitempty"`
+}
+
+type candidateStudent struct {
+	ID        int    `json:"id"`
+	Firstname string `json:"firstname"`
+	Name      string `json:"name"`
+	Domain    string `json:"domain"`
+	StudyYear int    `json:"studyYear"`
+}
+
+type Candidate struct {
+	Rank            int                `json:"rank"`
+	Registration    Registration       `json:"registration"`
+	Student         candidateStudent   `json:"student"`
+	AdvisorPriority int                `json:"advisorPriority"`
+	AppliedAt       time.Time          `json:"appliedAt"`
+	Criteria        map[string]float64 `json:"criteria"`
+	Score           float64            `json:"score"`
+}
+
+func loadSelection(ctx context.Context, offerID string) (Selection, error) {
+	sel := Selection{OfferID: offerID}
+	err := db.QueryRow(ctx,
+		"SELECT deadline, seats, weights, closed_at FROM offer_selections WHERE offer_id=$1", offerID).
+		Scan(&sel.Deadline, &sel.Seats, &sel.Weights, &sel.ClosedAt)
+	return sel, err
+}
+
+// requiredDomainMatch is the minimum domainMatch of the registrations to an offer
+func requiredDomainMatch(ctx context.Context, offerID string) (float64, error) {
+	_, err := loadSelection(ctx, offerID)
+	switch {
+	case err == pgx.ErrNoRows:
+		return exactDomainMatch, nil
+	case err != nil:
+		return 0, err
+	}
+	return relatedDomainMatch, nil
+}
+
+// applyToSelection records the application of an eligible student to an open selection.
+// The selection is locked in share mode until the application is committed, so closeSelection
+// either sees it or runs before and makes it errSelectionClosed.
+func applyToSelection(ctx context.Context, studentID int, sel Selection) (Registration, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return Registration{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		"SELECT closed_at, deadline FROM offer_selections WHERE offer_id=$1 FOR SHARE",
+		sel.OfferID).Scan(&sel.ClosedAt, &sel.Deadline)
+	if err == pgx.ErrNoRows || err == nil && (sel.ClosedAt != nil || !sel.Deadline.After(time.Now())) {
+		return Registration{}, errSelectionClosed
+	}
+	if err != nil {
+		return Registration{}, err
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM registrations WHERE student_id=$1 AND offer_id=$2 AND status = ANY($3))",
+		studentID, sel.OfferID, activeStatuses).Scan(&exists)
+	if err != nil {
+		return Registration{}, err
+	}
+	if exists {
+		return Registration{}, errAlreadyApplied
+	}
+
+	reg := Registration{
+		StudentID: studentID,
+		OfferID:   sel.OfferID,
+		Status:    statusApplied,
+		Message:   "Application received, candidates are selected on " + sel.Deadline.UTC().Format("2006-01-02 15:04 MST"),
+	}
+	if err := insertRegistration(ctx, tx, &reg); err != nil {
+		return Registration{}, err
+	}
+	return reg, tx.Commit(ctx)
+}
+
+// loadCandidates returns the registrations to the offer in the given statuses, ranked.
+// With forUpdate the registrations stay locked until the end of tx.
+func loadCandidates(ctx context.Context, tx pgx.Tx, offerID, offerDomain string, statuses []string, weights SelectionWeights, forUpdate bool) ([]Candidate, error) {
+	query := `
+		SELECT r.id, r.student_id, r.offer_id, r.status, r.message, r.advisor_priority, r.created_at,
+		       s.firstname, s.name, s.domain, COALESCE(s.study_year, 0)
+		FROM registrations r JOIN students s ON s.id = r.student_id
+		WHERE r.offer_id=$1 AND r.status = ANY($2)
+		ORDER BY r.id`
+	if forUpdate {
+		query += " FOR UPDATE OF r"
+	}
+	rows, err := tx.Query(ctx, query, offerID, statuses)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Candidate, error) {
+		var c Candidate
+		reg, s := &c.Registration, &c.Student
+		err := row.Scan(&reg.ID, &reg.StudentID, &reg.OfferID, &reg.Status, &reg.Message, &c.AdvisorPriority, &c.AppliedAt,
+			&s.Firstname, &s.Name, &s.Domain, &s.StudyYear)
+		s.ID = reg.StudentID
+		return c, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rankCandidates(candidates, offerDomain, weights)
+	return candidates, nil
+}
+
+// rankCandidates scores the candidates and sorts them, best first. Criteria other than the
+// domain match are scaled min-max among the candidates.
+func rankCandidates(candidates []Candidate, offerDomain string, weights SelectionWeights) {
+	scale := func(value func(c Candidate) float64, higherIsBetter bool) []float64 {
+		scaled := make([]float64, len(candidates))
+		if len(candidates) == 0 {
+			return scaled
+		}
+		min, max := value(candidates[0]), value(candidates[0])
+		for _, c := range candidates {
+			v := value(c)
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+		for i, c := range candidates {
+			scaled[i] = 1
+			if max > min {
+				scaled[i] = (value(c) - min) / (max - min)
+				if !higherIsBetter {
+					scaled[i] = 1 - scaled[i]
+				}
+			}
+		}
+		return scaled
+	}
+	studyYear := scale(func(c Candidate) float64 { return float64(c.Student.StudyYear) }, true)
+	applicationTime := scale(func(c Candidate) float64 { return float64(c.AppliedAt.UnixNano()) }, false)
+	advisorPriority := scale(func(c Candidate) float64 { return float64(c.AdvisorPriority) }, true)
+
+	for i := range candidates {
+		c := &candidates[i]
+		c.Criteria = map[string]float64{
+			"domainMatch":     domainMatch(c.Student.Domain, offerDomain),
+			"studyYear":       studyYear[i],
+			"applicationTime": applicationTime[i],
+			"advisorPriority": advisorPriority[i],
+		}
+		c.Score = weights.DomainMatch*c.Criteria["domainMatch"] +
+			weights.StudyYear*c.Criteria["studyYear"] +
+			weights.ApplicationTime*c.Criteria["applicationTime"] +
+			weights.AdvisorPriority*c.Criteria["advisorPriority"]
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.AppliedAt.Equal(b.AppliedAt) {
+			return a.AppliedAt.Before(b.AppliedAt)
+		}
+		return a.Registration.ID < b.Registration.ID
+	})
+	for i := range candidates {
+		candidates[i].Rank = i + 1
+	}
+}
+
+// closeSelection ranks the applications of a selection past its deadline: the best ones get a
+// seat, the others are waitlisted. Candidates no longer eligible (offer or domain changed) are rejected.
+func closeSelection(ctx context.Context, offerID string) error {
+	offer, err := offers.Get(ctx, offerID)
+	var current *ErasmumuOffer
+	switch {
+	case errors.Is(err, errOfferNotFound):
+	case err != nil:
+		return err
+	default:
+		current = &offer
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// SKIP LOCKED: another instance is already closing it
+	sel := Selection{OfferID: offerID}
+	err = tx.QueryRow(ctx,
+		"SELECT deadline, seats, weights FROM offer_selections WHERE offer_id=$1 AND closed_at IS NULL FOR UPDATE SKIP LOCKED",
+		offerID).Scan(&sel.Deadline, &sel.Seats, &sel.Weights)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	offerDomain := ""
+	if current != nil {
+		offerDomain = current.Domain
+	}
+	candidates, err := loadCandidates(ctx, tx, offerID, offerDomain, []string{statusApplied}, sel.Weights, true)
+	if err != nil {
+		return err
+	}
+
+	var selected []int
+	waitlisted := 0
+	for _, c := range candidates {
+		status, message := statusRejected, "Offer is not available"
+		if current != nil {
+			status, message = checkEligibility(Student{Domain: c.Student.Domain}, *current, relatedDomainMatch)
+		}
+		switch {
+		case status != statusApproved:
+		case len(selected) < sel.Seats:
+			status, message = statusPending, "Selected, reserving a seat"
+			selected = append(selected, c.Registration.ID)
+		default:
+			waitlisted++
+			status = statusWaitlisted
+		}
+
+		if status == statusWaitlisted {
+			message, err = waitlistRegistration(ctx, tx, c.Registration.ID, "Not selected")
+		} else {
+			err = setRegistrationStatus(ctx, tx, c.Registration.ID, status, message)
+		}
+		if err != nil {
+			return err
+		}
+		if status == statusPending {
+			if err := startSaga(ctx, tx, c.Registration.ID, offerID); err != nil {
+				return err
+			}
+		}
+		if err := notifyStudent(ctx, tx, c.Registration.StudentID, c.Registration.ID, message); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE offer_selections SET closed_at=now() WHERE offer_id=$1", offerID); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	fmt.Printf("Selection of offer %s closed: %d selected, %d waitlisted, %d rejected\n",
+		offerID, len(selected), waitlisted, len(candidates)-len(selected)-waitlisted)
+	for _, regID := range selected {
+		runReservation(ctx, regID, offerID)
+	}
+	return nil
+}
+
+// runSelectionScheduler closes the selections whose deadline passed
+func runSelectionScheduler(interval time.Duration) {
+	for range time.Tick(interval) {
+		ctx := context.Background()
+		rows, err := db.Query(ctx, "SELECT offer_id FROM offer_selections WHERE closed_at IS NULL AND deadline <= now() ORDER BY deadline")
+		if err != nil {
+			fmt.Printf("Selection scheduler: %v\n", err)
+			continue
+		}
+		offerIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			fmt.Printf("Selection scheduler: %v\n", err)
+			continue
+		}
+
+		for _, id := range offerIDs {
+			if err := closeSelection(ctx, id); err != nil {
+				fmt.Printf("Selection of offer %s: %v\n", id, err)
+			}
+		}
+	}
+}
+
+// PUT /offer/{offerId}/selection
+// Puts the offer in selection mode, or changes its selection until it is closed.
+func configureSelection(w http.ResponseWriter, r *http.Request) {
+	offerID := chi.URLParam(r, "offerId")
+	input := Selection{Weights: defaultSelectionWeights}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if input.Deadline.IsZero() {
+		http.Error(w, "deadline is required", http.StatusBadRequest)
+		return
+	}
+	if input.Seats < 1 {
+		http.Error(w, "seats must be at least 1", http.StatusBadRequest)
+		return
+	}
+	wt := input.Weights
+	if wt.DomainMatch < 0 || wt.StudyYear < 0 || wt.ApplicationTime < 0 || wt.AdvisorPriority < 0 {
+		http.Error(w, "weights can't be negative", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := offers.Get(r.Context(), offerID); errors.Is(err, errOfferNotFound) {
+		http.Error(w, "Offer not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	sel := Selection{OfferID: offerID}
+	err := db.QueryRow(context.Background(), `
+		INSERT INTO offer_selections (offer_id, deadline, seats, weights) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (offer_id) DO UPDATE SET deadline=EXCLUDED.deadline, seats=EXCLUDED.seats, weights=EXCLUDED.weights
+		WHERE offer_selections.closed_at IS NULL
+		RETURNING deadline, seats, weights, closed_at`,
+		offerID, input.Deadline, input.Seats, input.Weights).Scan(&sel.Deadline, &sel.Seats, &sel.Weights, &sel.ClosedAt)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Selection is already closed", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, sel)
+}
+
+// GET /offer/{offerId}/candidates
+// Active registrations to the offer, ranked as the selection does (default weights outside selection mode).
+func getCandidates(w http.ResponseWriter, r *http.Request) {
+	offerID := chi.URLParam(r, "offerId")
+	ctx := context.Background()
+
+	var selection *Selection
+	weights := defaultSelectionWeights
+	sel, err := loadSelection(ctx, offerID)
+	if err == nil {
+		selection, weights = &sel, sel.Weights
+	} else if err != pgx.ErrNoRows {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	offer, err := offers.Get(r.Context(), offerID)
+	if errors.Is(err, errOfferNotFound) {
+		http.Error(w, "Offer not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+	candidates, err := loadCandidates(ctx, tx, offerID, offer.Domain, activeStatuses, weights, false)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if candidates == nil {
+		candidates = []Candidate{}
+	}
+
+	jsonResponse(w, http.StatusOK, map[string]interface{}{
+		"selection":  selection,
+		"candidates": candidates,
+	})
+}
+
+// PUT /internship/{id}/priority
+func setAdvisorPriority(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var input struct {
+		AdvisorPriority int `json:"advisorPriority"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tag, err := db.Exec(context.Background(),
+		"UPDATE registrations SET advisor_priority=$1 WHERE id=$2 AND status = ANY($3)",
+		input.AdvisorPriority, id, activeStatuses)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		http.Error(w, "Active registration not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,22 +3,42 @@ package main
 import (
 	"context"
 	"encoding/json"
-
+	"errors"
+	"fmt"
 	"net/http"
+	"net/mail"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Student struct {
-	ID        int    `json:"id"`
-	Firstname string `json:"firstname"`
-	Name      string `json:"name"`
-	Domain    string `json:"domain"`
+	ID        int        `json:"id"`
+	Firstname string     `json:"firstname"`
+	Name      string     `json:"name"`
+	Domain    string     `json:"domain"`
+	Email     string     `json:"email,omitempty"`
+	StudyYear int        `json:"studyYear,omitempty"` // 1 to 8, 0 when unknown
+	Cohort    string     `json:"cohort,omitempty"`    // promotion, e.g. "2027"
+	Languages []Language `json:"languages"`
+	// Registrar's identifier, used with email to match students on import
+	StudentNumber string `json:"studentNumber,omitempty"`
+}
+
+type Language struct {
+	Language string `json:"language"`
+	Level    string `json:"level"` // CEFR level (A1 to C2) or "native"
 }
 
+var languageLevels = map[string]bool{"A1": true, "A2": true, "B1": true, "B2": true, "C1": true, "C2": true, "NATIVE": true}
+
+// Columns read by scanStudent, in order
+const studentColumns = "id, firstname, name, domain, COALESCE(email, ''), COALESCE(study_year, 0), COALESCE(cohort, ''), languages, COALESCE(student_number, '')"
+
 // Global DB connection pool
 var db *pgxpool.Pool
 
@@ -29,6 +49,108 @@ func jsonResponse(w http.ResponseWriter, status int, payload interface{}) {
 	json.NewEncoder(w).Encode(payload)
 }
 
+func scanStudent(row pgx.Row) (Student, error) {
+	var s Student
+	err := row.Scan(&s.ID, &s.Firstname, &s.Name, &s.Domain, &s.Email, &s.StudyYear, &s.Cohort, &s.Languages, &s.StudentNumber)
+	if s.Languages == nil {
+		s.Languages = []Language{}
+	}
+	return s, err
+}
+
+// loadStudent returns a student that isn't deleted, or pgx.ErrNoRows
+func loadStudent(ctx context.Context, id int) (Student, error) {
+	return scanStudent(db.QueryRow(ctx,
+		"SELECT "+studentColumns+" FROM students WHERE id=$1 AND deleted_at IS NULL", id))
+}
+
+// normalize trims the input and checks it, returning a message fit for a 400 answer
+func (s *Student) normalize() error {
+	s.Firstname = strings.TrimSpace(s.Firstname)
+	s.Name = strings.TrimSpace(s.Name)
+	s.Domain = strings.TrimSpace(s.Domain)
+	s.Email = strings.TrimSpace(s.Email)
+	s.Cohort = strings.TrimSpace(s.Cohort)
+	s.StudentNumber = strings.TrimSpace(s.StudentNumber)
+
+	if s.Firstname == "" || s.Name == "" || s.Domain == "" {
+		return errors.New("firstname, name and domain are required")
+	}
+	if s.Email != "" {
+		addr, err := mail.ParseAddress(s.Email)
+		if err != nil || addr.Address != s.Email {
+			return fmt.Errorf("invalid email %q", s.Email)
+		}
+	}
+	if s.StudyYear < 0 || s.StudyYear > 8 {
+		return errors.New("studyYear must be between 1 and 8, or 0 when unknown")
+	}
+
+	seen := map[string]bool{}
+	languages := []Language{}
+	for _, l := range s.Languages {
+		l.Language = strings.TrimSpace(l.Language)
+		l.Level = strings.ToUpper(strings.TrimSpace(l.Level))
+		if l.Language == "" {
+			return errors.New("language name is required")
+		}
+		if !languageLevels[l.Level] {
+			return fmt.Errorf("invalid level %q for %s, expected A1 to C2 or native", l.Level, l.Language)
+		}
+		if l.Level == "NATIVE" {
+			l.Level = "native"
+		}
+		if seen[strings.ToLower(l.Language)] {
+			return fmt.Errorf("language %s listed twice", l.Language)
+		}
+		seen[strings.ToLower(l.Language)] = true
+		languages = append(languages, l)
+	}
+	s.Languages = languages
+	return nil
+}
+
+// isUniqueViolation reports a unique constraint failure (students_email_key, ...)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// uniqueViolationMessage explains which unique field is already taken
+func uniqueViolationMessage(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == "students_student_number_key" {
+		return "Student number already used by another student"
+	}
+	return "Email already used by another student"
+}
+
+// insertStudent saves a normalized student (setting its ID) and records the student.created event
+func insertStudent(ctx context.Context, tx pgx.Tx, s *Student) error {
+	err := tx.QueryRow(ctx,
+		"INSERT INTO students (firstname, name, domain, email, study_year, cohort, languages, student_number) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, 0), NULLIF($6, ''), $7, NULLIF($8, '')) RETURNING id",
+		s.Firstname, s.Name, s.Domain, s.Email, s.StudyYear, s.Cohort, s.Languages, s.StudentNumber).Scan(&s.ID)
+	if err != nil {
+		return err
+	}
+	return enqueueEvent(ctx, tx, aggregateStudent, s.ID, eventStudentCreated, s)
+}
+
+// saveStudent replaces every field of the student s.ID and records the student.updated event.
+// It returns pgx.ErrNoRows when the student doesn't exist.
+func saveStudent(ctx context.Context, tx pgx.Tx, s *Student) error {
+	cmdTag, err := tx.Exec(ctx,
+		"UPDATE students SET firstname=$1, name=$2, domain=$3, email=NULLIF($4, ''), study_year=NULLIF($5, 0), cohort=NULLIF($6, ''), languages=$7, student_number=NULLIF($8, '') WHERE id=$9 AND deleted_at IS NULL",
+		s.Firstname, s.Name, s.Domain, s.Email, s.StudyYear, s.Cohort, s.Languages, s.StudentNumber, s.ID)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return enqueueEvent(ctx, tx, aggregateStudent, s.ID, eventStudentUpdated, s)
+}
+
 // POST /student
 func createStudent(w http.ResponseWriter, r *http.Request) {
 	var s Student
@@ -36,14 +158,32 @@ func createStudent(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := s.normalize(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	err := db.QueryRow(context.Background(),
-		"INSERT INTO students (firstname, name, domain) VALUES ($1, $2, $3) RETURNING id",
-		s.Firstname, s.Name, s.Domain).Scan(&s.ID)
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	defer tx.Rollback(ctx)
+
+	err = insertStudent(ctx, tx, &s)
+	if isUniqueViolation(err) {
+		http.Error(w, uniqueViolationMessage(err), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	jsonResponse(w, http.StatusCreated, s)
 }
@@ -57,9 +197,7 @@ func getStudent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var s Student
-	err = db.QueryRow(context.Background(),
-		"SELECT id, firstname, name, domain FROM students WHERE id=$1", id).Scan(&s.ID, &s.Firstname, &s.Name, &s.Domain)
+	s, err := loadStudent(context.Background(), id)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			http.Error(w, "Student not found", http.StatusNotFound)
@@ -72,34 +210,108 @@ func getStudent(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, http.StatusOK, s)
 }
 
-// GET /student?domain=<domain>
+// Sort keys accepted by GET /student, prefix with "-" for descending order
+var studentSortColumns = map[string]string{
+	"id":        "id",
+	"name":      "lower(name)",
+	"firstname": "lower(firstname)",
+	"studyYear": "study_year",
+	"cohort":    "cohort",
+	"createdAt": "created_at",
+}
+
+// GET /student?domain=<domain>&q=<name>&cohort=<cohort>&studyYear=<year>&sort=<key>&page=<n>&pageSize=<n>
+// The total number of matches is returned in X-Total-Count.
 func getStudents(w http.ResponseWriter, r *http.Request) {
-	domain := r.URL.Query().Get("domain")
-	
-	query := "SELECT id, firstname, name, domain FROM students"
+	query := r.URL.Query()
+
+	where := " WHERE deleted_at IS NULL"
 	args := []interface{}{}
-	if domain != "" {
-		query += " WHERE domain=$1"
-		args = append(args, domain)
+	addFilter := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		where += fmt.Sprintf(" AND "+clause, len(args))
+	}
+	if domain := query.Get("domain"); domain != "" {
+		addFilter("domain=$%d", domain)
+	}
+	if q := strings.TrimSpace(query.Get("q")); q != "" {
+		// Case-insensitive search on "firstname name", LIKE wildcards in q are literal
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(q)
+		addFilter("(firstname || ' ' || name) ILIKE '%%' || $%d || '%%'", escaped)
+	}
+	if cohort := query.Get("cohort"); cohort != "" {
+		addFilter("cohort=$%d", cohort)
+	}
+	if year := query.Get("studyYear"); year != "" {
+		y, err := strconv.Atoi(year)
+		if err != nil {
+			http.Error(w, "Invalid studyYear", http.StatusBadRequest)
+			return
+		}
+		addFilter("study_year=$%d", y)
+	}
+
+	sortKey, direction := strings.TrimPrefix(query.Get("sort"), "-"), "ASC"
+	if strings.HasPrefix(query.Get("sort"), "-") {
+		direction = "DESC"
+	}
+	if sortKey == "" {
+		sortKey = "id"
+	}
+	column, ok := studentSortColumns[sortKey]
+	if !ok {
+		http.Error(w, "Invalid sort, expected one of id, name, firstname, studyYear, cohort, createdAt", http.StatusBadRequest)
+		return
+	}
+
+	page, pageSize := 1, 50
+	if v := query.Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p < 1 {
+			http.Error(w, "Invalid page", http.StatusBadRequest)
+			return
+		}
+		page = p
+	}
+	if v := query.Get("pageSize"); v != "" {
+		ps, err := strconv.Atoi(v)
+		if err != nil || ps < 1 || ps > 200 {
+			http.Error(w, "Invalid pageSize, expected 1 to 200", http.StatusBadRequest)
+			return
+		}
+		pageSize = ps
+	}
+
+	ctx := context.Background()
+	var total int
+	if err := db.QueryRow(ctx, "SELECT count(*) FROM students"+where, args...).Scan(&total); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	rows, err := db.Query(context.Background(), query, args...)
+	args = append(args, pageSize, (page-1)*pageSize)
+	rows, err := db.Query(ctx,
+		fmt.Sprintf("SELECT %s FROM students%s ORDER BY %s %s NULLS LAST, id LIMIT $%d OFFSET $%d",
+			studentColumns, where, column, direction, len(args)-1, len(args)),
+		args...)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	defer rows.Close()
 
-	var students []Student
+	// Return empty array instead of null
+	students := []Student{}
 	for rows.Next() {
-		var s Student
-		if err := rows.Scan(&s.ID, &s.Firstname, &s.Name, &s.Domain); err != nil {
+		s, err := scanStudent(rows)
+		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 		students = append(students, s)
 	}
 
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
 	jsonResponse(w, http.StatusOK, students)
 }
 
@@ -117,41 +329,37 @@ func updateStudent(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := s.normalize(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	cmdTag, err := db.Exec(context.Background(),
-		"UPDATE students SET firstname=$1, name=$2, domain=$3 WHERE id=$4",
-		s.Firstname, s.Name, s.Domain, id)
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if cmdTag.RowsAffected() == 0 {
-		http.Error(w, "Student not found", http.StatusNotFound)
+	defer tx.Rollback(ctx)
+
+	s.ID = id
+	err = saveStudent(ctx, tx, &s)
+	if isUniqueViolation(err) {
+		http.Error(w, uniqueViolationMessage(err), http.StatusConflict)
 		return
 	}
-    
-    s.ID = id
-	jsonResponse(w, http.StatusOK, s)
-}
-
-// DELETE /student/:id
-func deleteStudent(w http.ResponseWriter, r *http.Request) {
-	idStr := chi.URLParam(r, "id")
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Student not found", http.StatusNotFound)
 		return
 	}
-
-	cmdTag, err := db.Exec(context.Background(), "DELETE FROM students WHERE id=$1", id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if cmdTag.RowsAffected() == 0 {
-		http.Error(w, "Student not found", http.StatusNotFound)
+	if err := tx.Commit(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	jsonResponse(w, http.StatusOK, s)
 }
@@ -0,0 +1,387 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CSV import and export of students, for the registrar's yearly onboarding.
+//
+// Columns are matched to student fields by header name. Common names (English and French) are
+// recognized; others are mapped with ?map=<header>:<field>,... Languages are written
+// "English:C1;German:B2" (quoted, or with commas, in ;-separated files).
+// Lines matching an existing student (by studentNumber or email) update the fields of their
+// columns only.
+
+// Header names (lower case) recognized without an explicit mapping
+var csvFieldAliases = map[string]string{
+	"firstname": "firstname", "first_name": "firstname", "first name": "firstname", "prenom": "firstname", "prénom": "firstname",
+	"name": "name", "lastname": "name", "last_name": "name", "last name": "name", "nom": "name",
+	"email": "email", "e-mail": "email", "mail": "email",
+	"domain": "domain", "field": "domain", "filiere": "domain", "filière": "domain",
+	"studyyear": "studyYear", "study_year": "studyYear", "year": "studyYear", "annee": "studyYear", "année": "studyYear",
+	"cohort": "cohort", "promotion": "cohort", "promo": "cohort",
+	"languages": "languages", "langues": "languages",
+	"studentnumber": "studentNumber", "student_number": "studentNumber", "student number": "studentNumber", "numero_etudiant": "studentNumber",
+}
+
+// Columns of the CSV export, also valid import headers
+var csvExportColumns = []string{"id", "studentNumber", "firstname", "name", "email", "domain", "studyYear", "cohort", "languages"}
+
+// Import modes
+const (
+	importAtomic = "atomic"  // every line is applied, or none when one fails
+	importPerRow = "per-row" // valid lines are applied, failing ones are reported
+)
+
+type importLineResult struct {
+	Line      int    `json:"line"`
+	Status    string `json:"status"` // created, updated or error
+	StudentID int    `json:"studentId,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type importReport struct {
+	Mode           string             `json:"mode"`
+	Applied        bool               `json:"applied"`
+	Created        int                `json:"created"`
+	Updated        int                `json:"updated"`
+	Failed         int                `json:"failed"`
+	IgnoredColumns []string           `json:"ignoredColumns"`
+	Results        []importLineResult `json:"results"`
+}
+
+// POST /student/import?mode=<atomic|per-row>&map=<header>:<field>,...&delimiter=<;>
+func importStudents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	mode := query.Get("mode")
+	if mode == "" {
+		mode = importAtomic
+	}
+	if mode != importAtomic && mode != importPerRow {
+		http.Error(w, "Invalid mode, expected atomic or per-row", http.StatusBadRequest)
+		return
+	}
+
+	reader := csv.NewReader(http.MaxBytesReader(w, r.Body, 10<<20))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	if d := query.Get("delimiter"); d != "" {
+		if len([]rune(d)) != 1 {
+			http.Error(w, "Invalid delimiter", http.StatusBadRequest)
+			return
+		}
+		reader.Comma = []rune(d)[0]
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		http.Error(w, "Invalid CSV header: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Registrar exports in French locales are often ;-separated
+	if query.Get("delimiter") == "" && len(header) == 1 && strings.Contains(header[0], ";") {
+		header = strings.Split(header[0], ";")
+		reader.Comma = ';'
+	}
+
+	columns, ignored, err := mapCSVColumns(header, query.Get("map"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	report := importReport{Mode: mode, IgnoredColumns: ignored, Results: []importLineResult{}}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var result importLineResult
+		if err != nil {
+			// FieldPos is only valid after a successful Read
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			result.Line = parseErr.Line
+			result.Status, result.Error = "error", parseErr.Err.Error()
+		} else {
+			result.Line, _ = reader.FieldPos(0)
+			result.StudentID, result.Status, err = importStudentRecord(ctx, tx, columns, record)
+			if err != nil {
+				result.Status, result.Error = "error", err.Error()
+			}
+		}
+
+		switch result.Status {
+		case "created":
+			report.Created++
+		case "updated":
+			report.Updated++
+		default:
+			report.Failed++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	if mode == importAtomic && report.Failed > 0 {
+		// Nothing is applied, the report tells which lines to fix
+		jsonResponse(w, http.StatusUnprocessableEntity, report)
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	report.Applied = true
+	jsonResponse(w, http.StatusOK, report)
+}
+
+// mapCSVColumns resolves the student field of every column (empty when ignored)
+func mapCSVColumns(header []string, mapping string) (columns []string, ignored []string, err error) {
+	explicit := map[string]string{}
+	if mapping != "" {
+		known := map[string]bool{}
+		for _, field := range csvFieldAliases {
+			known[field] = true
+		}
+		for _, pair := range strings.Split(mapping, ",") {
+			from, field, ok := strings.Cut(pair, ":")
+			if !ok || !known[strings.TrimSpace(field)] {
+				return nil, nil, fmt.Errorf("invalid mapping %q, expected <header>:<field>", pair)
+			}
+			explicit[strings.ToLower(strings.TrimSpace(from))] = strings.TrimSpace(field)
+		}
+	}
+
+	columns = make([]string, len(header))
+	seen := map[string]bool{}
+	for i, h := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\uFEFF")))
+		field, ok := explicit[name]
+		if !ok {
+			field, ok = csvFieldAliases[name]
+		}
+		if !ok {
+			ignored = append(ignored, h)
+			continue
+		}
+		if seen[field] {
+			return nil, nil, fmt.Errorf("several columns map to %s", field)
+		}
+		seen[field] = true
+		columns[i] = field
+	}
+	for _, required := range []string{"firstname", "name", "domain"} {
+		if !seen[required] {
+			return nil, nil, fmt.Errorf("no column for %s", required)
+		}
+	}
+	if !seen["email"] && !seen["studentNumber"] {
+		return nil, nil, errors.New("no column for email or studentNumber, needed to match existing students")
+	}
+	if ignored == nil {
+		ignored = []string{}
+	}
+	return columns, ignored, nil
+}
+
+// importStudentRecord upserts one CSV line inside a savepoint, so a failing line doesn't abort the
+// transaction. A matched student keeps the fields of the columns missing from the file.
+func importStudentRecord(ctx context.Context, tx pgx.Tx, columns []string, record []string) (id int, status string, err error) {
+	s := Student{}
+	if err := applyStudentRecord(&s, columns, record); err != nil {
+		return 0, "", err
+	}
+	if s.Email == "" && s.StudentNumber == "" {
+		return 0, "", errors.New("email or studentNumber is required")
+	}
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	defer savepoint.Rollback(ctx)
+
+	rows, err := savepoint.Query(ctx,
+		"SELECT "+studentColumns+" FROM students WHERE deleted_at IS NULL AND ((student_number=$1 AND $1 <> '') OR (lower(email)=lower($2) AND $2 <> '')) FOR UPDATE",
+		s.StudentNumber, s.Email)
+	if err != nil {
+		return 0, "", err
+	}
+	matches, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Student, error) {
+		return scanStudent(row)
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	switch len(matches) {
+	case 0:
+		status = "created"
+	case 1:
+		s, status = matches[0], "updated"
+		if err := applyStudentRecord(&s, columns, record); err != nil {
+			return 0, "", err
+		}
+	default:
+		return 0, "", errors.New("studentNumber and email match different students")
+	}
+	if err := s.normalize(); err != nil {
+		return 0, "", err
+	}
+	if status == "created" {
+		err = insertStudent(ctx, savepoint, &s)
+	} else {
+		err = saveStudent(ctx, savepoint, &s)
+	}
+	if isUniqueViolation(err) {
+		return 0, "", errors.New(uniqueViolationMessage(err))
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	if err := savepoint.Commit(ctx); err != nil {
+		return 0, "", err
+	}
+	return s.ID, status, nil
+}
+
+// applyStudentRecord sets the fields of the mapped columns of a CSV line, an empty cell clearing
+// its field
+func applyStudentRecord(s *Student, columns []string, record []string) error {
+	for i, value := range record {
+		if i >= len(columns) || columns[i] == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch columns[i] {
+		case "firstname":
+			s.Firstname = value
+		case "name":
+			s.Name = value
+		case "email":
+			s.Email = value
+		case "domain":
+			s.Domain = value
+		case "cohort":
+			s.Cohort = value
+		case "studentNumber":
+			s.StudentNumber = value
+		case "studyYear":
+			if value == "" {
+				s.StudyYear = 0
+				continue
+			}
+			year, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid studyYear %q", value)
+			}
+			s.StudyYear = year
+		case "languages":
+			languages, err := parseLanguages(value)
+			if err != nil {
+				return err
+			}
+			s.Languages = languages
+		}
+	}
+	return nil
+}
+
+// parseLanguages reads "English:C1;German:B2", or "English:C1,German:B2" in ;-separated files
+func parseLanguages(value string) ([]Language, error) {
+	var languages []Language
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' }) {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		language, level, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid language %q, expected <language>:<level>", item)
+		}
+		languages = append(languages, Language{Language: language, Level: level})
+	}
+	return languages, nil
+}
+
+func formatLanguages(languages []Language) string {
+	items := make([]string, len(languages))
+	for i, l := range languages {
+		items[i] = l.Language + ":" + l.Level
+	}
+	return strings.Join(items, ";")
+}
+
+// GET /student/export?format=<csv|json>&domain=<domain>
+func exportStudents(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		http.Error(w, "Invalid format, expected csv or json", http.StatusBadRequest)
+		return
+	}
+
+	query := "SELECT " + studentColumns + " FROM students WHERE deleted_at IS NULL"
+	args := []interface{}{}
+	if domain := r.URL.Query().Get("domain"); domain != "" {
+		query += " AND domain=$1"
+		args = append(args, domain)
+	}
+	rows, err := db.Query(context.Background(), query+" ORDER BY id", args...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	students, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Student, error) {
+		return scanStudent(row)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if format == "json" {
+		if students == nil {
+			students = []Student{}
+		}
+		jsonResponse(w, http.StatusOK, students)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="students.csv"`)
+	writer := csv.NewWriter(w)
+	writer.Write(csvExportColumns)
+	for _, s := range students {
+		year := ""
+		if s.StudyYear > 0 {
+			year = strconv.Itoa(s.StudyYear)
+		}
+		writer.Write([]string{
+			strconv.Itoa(s.ID), s.StudentNumber, s.Firstname, s.Name, s.Email, s.Domain, year, s.Cohort, formatLanguages(s.Languages),
+		})
+	}
+	writer.Flush()
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// Student deletion policies, set with STUDENT_DELETE_POLICY (default block). A request may ask for
+// a stricter policy with ?policy=, never a looser one: block is the strictest, then cascade, then
+// anonymize.
+//
+// Students are soft-deleted (deleted_at) rather than removed, so their registrations keep
+// referencing an existing row and stay readable through /internship/{id}.
+const (
+	deletePolicyBlock     = "block"     // refuse (409) while the student has active registrations
+	deletePolicyCascade   = "cascade"   // cancel the active registrations and give their seats to the waitlist
+	deletePolicyAnonymize = "anonymize" // cascade, and also erase the student's personal fields
+)
+
+// deletePolicyStrictness ranks the policies, the strictest first
+var deletePolicyStrictness = map[string]int{deletePolicyBlock: 0, deletePolicyCascade: 1, deletePolicyAnonymize: 2}
+
+type deletionConflict struct {
+	Error         string         `json:"error"`
+	Registrations []Registration `json:"registrations"`
+}
+
+// DELETE /student/:id?policy=<block|cascade|anonymize>
+func deleteStudent(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	configured := os.Getenv("STUDENT_DELETE_POLICY")
+	if configured == "" {
+		configured = deletePolicyBlock
+	}
+	if _, ok := deletePolicyStrictness[configured]; !ok {
+		http.Error(w, "Invalid STUDENT_DELETE_POLICY, expected block, cascade or anonymize", http.StatusInternalServerError)
+		return
+	}
+	policy := r.URL.Query().Get("policy")
+	if policy == "" {
+		policy = configured
+	}
+	strictness, ok := deletePolicyStrictness[policy]
+	if !ok {
+		http.Error(w, "Invalid policy, expected block, cascade or anonymize", http.StatusBadRequest)
+		return
+	}
+	if strictness > deletePolicyStrictness[configured] {
+		http.Error(w, "Policy "+policy+" is looser than the configured "+configured+" policy", http.StatusForbidden)
+		return
+	}
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	// Locking the student also holds back registrations being created for it (foreign key)
+	var found int
+	err = tx.QueryRow(ctx, "SELECT id FROM students WHERE id=$1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&found)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT id, student_id, offer_id, status, message FROM registrations WHERE student_id=$1 AND status = ANY($2) ORDER BY id FOR UPDATE",
+		id, activeStatuses)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	active, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Registration, error) {
+		var reg Registration
+		err := row.Scan(&reg.ID, &reg.StudentID, &reg.OfferID, &reg.Status, &reg.Message)
+		return reg, err
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if policy == deletePolicyBlock && len(active) > 0 {
+		jsonResponse(w, http.StatusConflict, deletionConflict{
+			Error:         "Student has active registrations",
+			Registrations: active,
+		})
+		return
+	}
+	for _, reg := range active {
+		if reg.Status == statusPending {
+			// A seat reservation is in flight, its saga would overwrite the cancellation
+			jsonResponse(w, http.StatusConflict, deletionConflict{
+				Error:         "A registration is being processed, retry later",
+				Registrations: []Registration{reg},
+			})
+			return
+		}
+	}
+
+	for _, reg := range active {
+		if err := cancelRegistration(ctx, tx, reg.ID, "Cancelled: the student was deleted"); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if policy == deletePolicyAnonymize {
+		if err := anonymizeStudent(ctx, tx, id); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if _, err := tx.Exec(ctx, "UPDATE students SET deleted_at=now() WHERE id=$1", id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	payload := map[string]interface{}{"id": id, "policy": policy}
+	if err := recordAudit(ctx, tx, id, auditStudentDeleted, payload); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := enqueueEvent(ctx, tx, aggregateStudent, id, eventStudentDeleted, payload); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for _, reg := range active {
+		if holdsSeat(reg.Status) {
+			freeSeat(ctx, reg.ID, reg.OfferID)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Personal fields of a student (JSON names), blanked by anonymizeStudent
+var anonymizedFields = []string{"firstname", "name", "email", "studentNumber"}
+
+// anonymizeStudent erases the personal fields of a student, keeping the row and its domain for statistics
+func anonymizeStudent(ctx context.Context, tx pgx.Tx, id int) error {
+	_, err := tx.Exec(ctx, "UPDATE students SET firstname='', name='', email=NULL, student_number=NULL WHERE id=$1", id)
+	return err
+}
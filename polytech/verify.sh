@@ -7,7 +7,7 @@ echo "Starting Verification..."
 
 # 1. Create Student
 echo "Creating Student (IT)..."
-RESPONSE=$(curl -s -X POST $BASE_URL/student -d '{"firstname":"John", "name":"Doe", "domain":"IT"}')
+RESPONSE=$(curl -s -X POST $BASE_URL/student -d '{"firstname":"John", "name":"Doe", "domain":"IT", "email":"john.doe.'$RANDOM'@example.com", "studyYear":4, "cohort":"2027", "languages":[{"language":"English","level":"C1"}]}')
 echo "Response: $RESPONSE"
 
 # Extract ID using grep/sed (simple approximation since jq might not be available)
@@ -22,6 +22,9 @@ fi
 echo "Listing Students..."
 curl -v $BASE_URL/student
 
+echo "Searching Students (q=doe, sorted by name, first page)..."
+curl -v "$BASE_URL/student?q=doe&sort=name&page=1&pageSize=10"
+
 # --- Integration Tests ---
 
 # 2. Create Offer (IT) - Valid
@@ -60,6 +63,95 @@ echo "Registering Student to Bio Offer..."
 REG_RESP_INVALID=$(curl -s -X POST $BASE_URL/internship -d "{\"studentId\":$STUDENT_ID, \"offerId\":\"$OFFER_ID_BIO\"}")
 echo "Registration Response (Should be Rejected): $REG_RESP_INVALID"
 
+# 6. Seat reservation: an offer with a single seat only approves the first registration
+echo "Creating Offer (IT, 1 seat) in Erasmumu..."
+OFFER_RESP_SEAT=$(curl -s -X POST http://localhost:8081/offer -d '{
+    "title": "IT Internship (1 seat)",
+    "link": "http://example.com",
+    "city": "Paris",
+    "domain": "IT",
+    "salary": 1000,
+    "available": true,
+    "seats": 1
+}')
+OFFER_ID_SEAT=$(echo $OFFER_RESP_SEAT | grep -o '"id":"[^"]*"' | cut -d'"' -f4)
+echo "Created 1-seat Offer ID: $OFFER_ID_SEAT"
+
+REG_RESP_SEAT1=$(curl -s -X POST $BASE_URL/internship -d "{\"studentId\":$STUDENT_ID, \"offerId\":\"$OFFER_ID_SEAT\"}")
+echo "First Registration (Should be Approved): $REG_RESP_SEAT1"
+REG_RESP_SEAT2=$(curl -s -X POST $BASE_URL/internship -d "{\"studentId\":$STUDENT_ID, \"offerId\":\"$OFFER_ID_SEAT\"}")
+echo "Second Registration (Should be Waitlisted, no seats left): $REG_RESP_SEAT2"
+echo "Waitlist:"
+curl -s $BASE_URL/offer/$OFFER_ID_SEAT/waitlist
+REG_ID_SEAT1=$(echo $REG_RESP_SEAT1 | grep -o '"id":[0-9]*' | cut -d':' -f2)
+REG_ID_SEAT2=$(echo $REG_RESP_SEAT2 | grep -o '"id":[0-9]*' | cut -d':' -f2)
+echo "Withdrawing the first registration..."
+curl -s -X POST $BASE_URL/internship/$REG_ID_SEAT1/withdraw
+echo "Second Registration after withdrawal (Should be Approved, promoted from the waitlist):"
+curl -s $BASE_URL/internship/$REG_ID_SEAT2
+
+# 6c. Agreement: confirmation is blocked until documents and signatures are complete
+echo "Confirming before the agreement is signed (Should be 409):"
+curl -s -X POST $BASE_URL/internship/$REG_ID_SEAT2/confirm
+echo "Uploading documents and signing..."
+echo "agreement" > /tmp/agreement.pdf
+echo "insurance" > /tmp/insurance.pdf
+curl -s -X POST "$BASE_URL/internship/$REG_ID_SEAT2/documents?type=agreement" -F "file=@/tmp/agreement.pdf"
+curl -s -X POST "$BASE_URL/internship/$REG_ID_SEAT2/documents?type=insurance" -F "file=@/tmp/insurance.pdf"
+for PARTY in student company school; do
+    curl -s -X POST $BASE_URL/internship/$REG_ID_SEAT2/signatures -d "{\"party\":\"$PARTY\", \"signedBy\":\"$PARTY signatory\"}" > /dev/null
+done
+curl -s $BASE_URL/internship/$REG_ID_SEAT2/agreement
+echo "Confirming (Should be confirmed):"
+curl -s -X POST $BASE_URL/internship/$REG_ID_SEAT2/confirm
+
+# 6b. Selection mode: registrations are collected until the deadline, then ranked
+echo "Creating Offer (IT, 2 seats, selection mode) in Erasmumu..."
+OFFER_RESP_SEL=$(curl -s -X POST http://localhost:8081/offer -d '{
+    "title": "IT Internship (selection)",
+    "link": "http://example.com",
+    "city": "Lyon",
+    "domain": "IT",
+    "salary": 1200,
+    "available": true,
+    "seats": 2
+}')
+OFFER_ID_SEL=$(echo $OFFER_RESP_SEL | grep -o '"id":"[^"]*"' | cut -d'"' -f4)
+curl -s -X PUT $BASE_URL/offer/$OFFER_ID_SEL/selection -d '{"deadline":"'$(date -u -d '+1 hour' +%Y-%m-%dT%H:%M:%SZ)'", "seats":2, "weights":{"studyYear":2}}'
+echo "Applying (Should be applied):"
+curl -s -X POST $BASE_URL/internship -d "{\"studentId\":$STUDENT_ID, \"offerId\":\"$OFFER_ID_SEL\"}"
+echo "Candidates:"
+curl -s $BASE_URL/offer/$OFFER_ID_SEL/candidates
+echo
+
+# 7. Offer change: the approved registration to the IT offer needs attention once its domain changes
+REG_ID_VALID=$(echo $REG_RESP_VALID | grep -o '"id":[0-9]*' | cut -d':' -f2)
+echo "Changing IT Offer domain to Biology..."
+curl -s -X PUT http://localhost:8081/offer/$OFFER_ID_IT -d '{
+    "title": "IT Internship",
+    "link": "http://example.com",
+    "city": "Paris",
+    "domain": "Biology",
+    "salary": 1000,
+    "available": true
+}'
+sleep 2
+echo "Registration after offer change (Should be needs_attention):"
+curl -s $BASE_URL/internship/$REG_ID_VALID
+echo "Student notifications:"
+curl -s $BASE_URL/student/$STUDENT_ID/notifications
+
+echo "Recommendations, favouring safety over salary:"
+curl -s "$BASE_URL/student/$STUDENT_ID/recommendations?safety=3&salary=1"
+echo
+
+echo "Readiness (database and MI8):"
+curl -s $BASE_URL/ready
+echo "City context from MI8:"
+curl -s $BASE_URL/city/Berlin/news
+curl -s $BASE_URL/city/Berlin/score
+curl -s "$BASE_URL/city/top?limit=5"
+echo
 
 echo "Getting specific student..."
 curl -v $BASE_URL/student/$STUDENT_ID
@@ -67,7 +159,23 @@ curl -v $BASE_URL/student/$STUDENT_ID
 echo "Updating Student..."
 curl -v -X PUT $BASE_URL/student/$STUDENT_ID -d '{"firstname":"Johnny", "name":"Doe", "domain":"IT"}'
 
-echo "Deleting Student..."
+echo "Deleting Student with active registrations, block policy (Should be 409)..."
+curl -v -X DELETE "$BASE_URL/student/$STUDENT_ID?policy=block"
+
+echo "Deleting Student, looser policy than configured (Should be 403)..."
+curl -v -X DELETE "$BASE_URL/student/$STUDENT_ID?policy=anonymize"
+
+echo "Deleting Student, cancelling its registrations (configured cascade policy)..."
 curl -v -X DELETE $BASE_URL/student/$STUDENT_ID
 
+echo "Registration after student deletion (Should be cancelled):"
+curl -s $BASE_URL/internship/$REG_ID_VALID
+
+echo "Importing students from CSV (;-separated, French headers)..."
+printf 'Prénom;Nom;Mail;Filière;Promo;Langues\nAlice;Martin;alice.'$RANDOM'@example.com;IT;2027;English:C1,German:B2\nBob;Durand;not-an-email;IT;2027;\n' > /tmp/students.csv
+curl -s -X POST "$BASE_URL/student/import?mode=per-row" --data-binary @/tmp/students.csv
+echo
+echo "Exporting IT students as CSV..."
+curl -s "$BASE_URL/student/export?format=csv&domain=IT"
+
 echo "Done."
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// Waitlist.
+//
+// Registrations that couldn't get a seat (no seats left when reserving, or not selected when a
+// selection closed) are waitlisted rather than rejected, each with a position in its offer's
+// waitlist. When a seat is given back (withdrawal, student deletion, cancellation) the first
+// waitlisted registration is promoted: eligibility is checked again, then it goes through the
+// seat reservation saga. Registrations no longer eligible are rejected and the next one is tried.
+// A promoted registration finding no seat goes back to the waitlist at its position.
+
+type waitlistEntry struct {
+	Position     int          `json:"position"`
+	Registration Registration `json:"registration"`
+}
+
+// waitlistRegistration puts a registration on its offer's waitlist, at the end unless it is coming
+// back to it. It returns the message stored on the registration, with its position.
+func waitlistRegistration(ctx context.Context, tx pgx.Tx, id int, reason string) (message string, err error) {
+	var offerID string
+	var waitlistPosition int64
+	err = tx.QueryRow(ctx,
+		"UPDATE registrations SET waitlist_position=COALESCE(waitlist_position, nextval('registrations_waitlist_seq')) WHERE id=$1 RETURNING offer_id, waitlist_position",
+		id).Scan(&offerID, &waitlistPosition)
+	if err != nil {
+		return "", err
+	}
+
+	var ahead int
+	err = tx.QueryRow(ctx,
+		"SELECT count(*) FROM registrations WHERE offer_id=$1 AND status=$2 AND waitlist_position < $3",
+		offerID, statusWaitlisted, waitlistPosition).Scan(&ahead)
+	if err != nil {
+		return "", err
+	}
+
+	message = fmt.Sprintf("%s, waitlisted at position %d", reason, ahead+1)
+	return message, setRegistrationStatus(ctx, tx, id, statusWaitlisted, message)
+}
+
+// freeSeat gives back the seat of a cancelled registration, then promotes the next waitlisted one
+func freeSeat(ctx context.Context, regID int, offerID string) {
+	if releaseHeldSeat(ctx, regID, offerID) {
+		promoteNext(ctx, offerID)
+	}
+}
+
+// promoteNext promotes the first eligible waitlisted registration of the offer
+func promoteNext(ctx context.Context, offerID string) {
+	offer, err := offers.Get(ctx, offerID)
+	var current *ErasmumuOffer
+	switch {
+	case errors.Is(err, errOfferNotFound):
+		// Nobody can be promoted, the waitlist is emptied below
+	case err != nil:
+		fmt.Printf("Waitlist of offer %s: %v\n", offerID, err)
+		return
+	default:
+		current = &offer
+	}
+	// A failed read mustn't reject candidates with a related domain, nobody is promoted this time
+	minMatch, err := requiredDomainMatch(ctx, offerID)
+	if err != nil {
+		fmt.Printf("Waitlist of offer %s: %v\n", offerID, err)
+		return
+	}
+
+	for {
+		regID, promoted, err := promoteFirst(ctx, offerID, current, minMatch)
+		if err != nil {
+			fmt.Printf("Waitlist of offer %s: %v\n", offerID, err)
+			return
+		}
+		if regID == 0 {
+			return
+		}
+		if promoted {
+			runReservation(ctx, regID, offerID)
+			return
+		}
+	}
+}
+
+// promoteFirst takes the head of the waitlist: it is promoted if still eligible, rejected otherwise.
+// regID is 0 when the waitlist is empty.
+func promoteFirst(ctx context.Context, offerID string, offer *ErasmumuOffer, minMatch float64) (regID int, promoted bool, err error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var studentID int
+	var domain string
+	var waitlistPosition int64
+	err = tx.QueryRow(ctx, `
+		SELECT r.id, r.student_id, s.domain, r.waitlist_position
+		FROM registrations r JOIN students s ON s.id = r.student_id
+		WHERE r.offer_id=$1 AND r.status=$2
+		ORDER BY r.waitlist_position, r.id
+		LIMIT 1
+		FOR UPDATE OF r SKIP LOCKED`,
+		offerID, statusWaitlisted).Scan(&regID, &studentID, &domain, &waitlistPosition)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	status, message := statusRejected, "Offer is not available"
+	if offer != nil {
+		status, message = checkEligibility(Student{Domain: domain}, *offer, minMatch)
+	}
+	if status == statusApproved {
+		message = "Promoted from the waitlist, reserving a seat"
+		if err := setRegistrationStatus(ctx, tx, regID, statusPending, message); err != nil {
+			return 0, false, err
+		}
+		if err := startSaga(ctx, tx, regID, offerID); err != nil {
+			return 0, false, err
+		}
+		payload := map[string]interface{}{"id": regID, "studentId": studentID, "offerId": offerID, "waitlistPosition": waitlistPosition}
+		if err := enqueueEvent(ctx, tx, aggregateRegistration, regID, eventRegistrationPromoted, payload); err != nil {
+			return 0, false, err
+		}
+	} else {
+		message = "Not promoted from the waitlist: " + message
+		if err := setRegistrationStatus(ctx, tx, regID, statusRejected, message); err != nil {
+			return 0, false, err
+		}
+	}
+	if err := notifyStudent(ctx, tx, studentID, regID, message); err != nil {
+		return 0, false, err
+	}
+	return regID, status == statusApproved, tx.Commit(ctx)
+}
+
+// POST /internship/{id}/withdraw
+func withdrawRegistration(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	var status, offerID string
+	err = tx.QueryRow(ctx, "SELECT status, offer_id FROM registrations WHERE id=$1 FOR UPDATE", id).Scan(&status, &offerID)
+	if err == pgx.ErrNoRows {
+		http.Error(w, "Registration not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	switch status {
+	case statusPending:
+		// The saga would overwrite the withdrawal
+		http.Error(w, "Registration is being processed, retry later", http.StatusConflict)
+		return
+	case statusApproved, statusNeedsAttention, statusApplied, statusWaitlisted, statusConfirmed:
+	default:
+		http.Error(w, "Registration is not active", http.StatusConflict)
+		return
+	}
+
+	if err := cancelRegistration(ctx, tx, id, "Withdrawn by the student"); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if holdsSeat(status) {
+		freeSeat(ctx, id, offerID)
+	}
+
+	reg, err := loadRegistration(ctx, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonResponse(w, http.StatusOK, reg)
+}
+
+// GET /offer/{offerId}/waitlist
+func getWaitlist(w http.ResponseWriter, r *http.Request) {
+	rows, err := db.Query(context.Background(), `
+		SELECT id, student_id, offer_id, status, message FROM registrations
+		WHERE offer_id=$1 AND status=$2
+		ORDER BY waitlist_position, id`,
+		chi.URLParam(r, "offerId"), statusWaitlisted)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (waitlistEntry, error) {
+		var e waitlistEntry
+		reg := &e.Registration
+		err := row.Scan(&reg.ID, &reg.StudentID, &reg.OfferID, &reg.Status, &reg.Message)
+		return e, err
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for i := range entries {
+		entries[i].Position = i + 1
+	}
+	if entries == nil {
+		entries = []waitlistEntry{}
+	}
+
+	jsonResponse(w, http.StatusOK, entries)
+}